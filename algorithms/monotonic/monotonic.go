@@ -0,0 +1,201 @@
+// Package monotonic implements the classic algorithms built on a monotonic
+// stack: a stack of indices whose items only increase, or only decrease, from
+// bottom to top. Every index is pushed and popped at most once, so each
+// algorithm runs in O(n).
+package monotonic
+
+import (
+	"cmp"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/constraints"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+// NextGreater returns, for every index, the index of the first later item
+// that is strictly greater, or -1 if there is none.
+func NextGreater[T cmp.Ordered](items []T) []int {
+	return next(items, func(top, item T) bool { return top < item })
+}
+
+// NextSmaller returns, for every index, the index of the first later item
+// that is strictly smaller, or -1 if there is none.
+func NextSmaller[T cmp.Ordered](items []T) []int {
+	return next(items, func(top, item T) bool { return top > item })
+}
+
+// PreviousGreater returns, for every index, the index of the last earlier
+// item that is strictly greater, or -1 if there is none.
+func PreviousGreater[T cmp.Ordered](items []T) []int {
+	return previous(items, func(top, item T) bool { return top <= item })
+}
+
+// PreviousSmaller returns, for every index, the index of the last earlier
+// item that is strictly smaller, or -1 if there is none.
+func PreviousSmaller[T cmp.Ordered](items []T) []int {
+	return previous(items, func(top, item T) bool { return top >= item })
+}
+
+// next scans left to right. Each item resolves the waiting indices it beats,
+// which are always on top of the stack since those it did not beat lie
+// below.
+func next[T any](items []T, beats func(top, item T) bool) []int {
+	result := filled(len(items), -1)
+	waiting := stack.NewOf[int]()
+
+	for i, item := range items {
+		for top, ok := waiting.TryPeek(); ok && beats(items[top], item); top, ok = waiting.TryPeek() {
+			waiting.Pop()
+			result[top] = i
+		}
+
+		waiting.Push(i)
+	}
+
+	return result
+}
+
+// previous scans left to right, popping the candidates that cannot be the
+// answer for the current item or any later one; whatever remains on top is
+// the answer.
+func previous[T any](items []T, obsolete func(top, item T) bool) []int {
+	result := filled(len(items), -1)
+	candidates := stack.NewOf[int]()
+
+	for i, item := range items {
+		for top, ok := candidates.TryPeek(); ok && obsolete(items[top], item); top, ok = candidates.TryPeek() {
+			candidates.Pop()
+		}
+
+		if top, ok := candidates.TryPeek(); ok {
+			result[i] = top
+		}
+
+		candidates.Push(i)
+	}
+
+	return result
+}
+
+// LargestRectangle returns the area of the largest rectangle that fits under
+// a histogram of bars of width 1. Heights must not be negative.
+func LargestRectangle[T constraints.Number](heights []T) T {
+	var largest T
+	bars := stack.NewOf[int]()
+
+	// A bar's rectangle extends until the first lower bar on either side. It is
+	// measured when a lower bar, or the end, pops it; the bar beneath it on the
+	// stack is the first lower one to its left.
+	for i := 0; i <= len(heights); i++ {
+		for top, ok := bars.TryPeek(); ok && (i == len(heights) || heights[top] > heights[i]); top, ok = bars.TryPeek() {
+			bars.Pop()
+
+			left := -1
+			if below, ok := bars.TryPeek(); ok {
+				left = below
+			}
+
+			largest = max(largest, heights[top]*T(i-left-1))
+		}
+
+		bars.Push(i)
+	}
+
+	return largest
+}
+
+// MaximalRectangle returns the area of the largest rectangle of true cells in
+// a matrix whose rows all have the same length. Each row is treated as the
+// base of a histogram whose bars are the runs of true cells ending in it.
+func MaximalRectangle(matrix [][]bool) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+
+	largest := 0
+	heights := make([]int, len(matrix[0]))
+
+	for _, row := range matrix {
+		for j, cell := range row {
+			if cell {
+				heights[j]++
+			} else {
+				heights[j] = 0
+			}
+		}
+
+		largest = max(largest, LargestRectangle(heights))
+	}
+
+	return largest
+}
+
+// StockSpan returns, for every day, how many consecutive days up to and
+// including it had a price no higher than that day's.
+func StockSpan[T cmp.Ordered](prices []T) []int {
+	spans := PreviousGreater(prices)
+
+	for i, previous := range spans {
+		spans[i] = i - previous
+	}
+
+	return spans
+}
+
+// TrapRainWater returns how much water a terrain of bars of width 1 holds
+// after rain. Heights must not be negative.
+func TrapRainWater[T constraints.Number](heights []T) T {
+	var water T
+	walls := stack.NewOf[int]()
+
+	// Water is added layer by layer: when a bar is higher than the one on top
+	// of the stack, that one is the floor of a basin whose walls are the bar
+	// and the one beneath the floor.
+	for i, height := range heights {
+		for floor, ok := walls.TryPeek(); ok && heights[floor] < height; floor, ok = walls.TryPeek() {
+			walls.Pop()
+
+			left, ok := walls.TryPeek()
+			if !ok {
+				break
+			}
+
+			depth := min(heights[left], height) - heights[floor]
+			water += depth * T(i-left-1)
+		}
+
+		walls.Push(i)
+	}
+
+	return water
+}
+
+// SumSubarrayMinimums returns the sum of the minimums of every contiguous,
+// non-empty subarray of items.
+func SumSubarrayMinimums[T constraints.Number](items []T) T {
+	// An item is the minimum of the subarrays that extend left to the
+	// previous strictly smaller item and right to the next smaller or equal
+	// one, which counts each subarray with tied minimums exactly once.
+	left := PreviousSmaller(items)
+	right := next(items, func(top, item T) bool { return top >= item })
+
+	var sum T
+	for i, item := range items {
+		end := right[i]
+		if end < 0 {
+			end = len(items)
+		}
+
+		sum += item * T((i-left[i])*(end-i))
+	}
+
+	return sum
+}
+
+func filled(n, value int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = value
+	}
+
+	return s
+}
@@ -0,0 +1,202 @@
+package monotonic
+
+import (
+	"math/rand"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+const trials = 200
+
+func randomInts(r *rand.Rand) []int {
+	items := make([]int, r.Intn(30))
+	for i := range items {
+		items[i] = r.Intn(10)
+	}
+
+	return items
+}
+
+func validateSlices(t *testing.T, got, want []int) {
+	t.Helper()
+
+	utils.ValidateResult(t, len(got), len(want))
+	for i := range want {
+		utils.ValidateResult(t, got[i], want[i])
+	}
+}
+
+func bruteNearest(items []int, step int, wins func(other, item int) bool) []int {
+	result := make([]int, len(items))
+
+	for i, item := range items {
+		result[i] = -1
+		for j := i + step; j >= 0 && j < len(items); j += step {
+			if wins(items[j], item) {
+				result[i] = j
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func TestNearest(t *testing.T) {
+	greater := func(other, item int) bool { return other > item }
+	smaller := func(other, item int) bool { return other < item }
+
+	t.Run("Finds nearest indices", func(t *testing.T) {
+		items := []int{2, 1, 2, 4, 3}
+
+		validateSlices(t, NextGreater(items), []int{3, 2, 3, -1, -1})
+		validateSlices(t, NextSmaller(items), []int{1, -1, -1, 4, -1})
+		validateSlices(t, PreviousGreater(items), []int{-1, 0, -1, -1, 3})
+		validateSlices(t, PreviousSmaller(items), []int{-1, -1, 1, 2, 2})
+	})
+
+	t.Run("Matches brute force", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+
+		for i := 0; i < trials; i++ {
+			items := randomInts(r)
+
+			validateSlices(t, NextGreater(items), bruteNearest(items, 1, greater))
+			validateSlices(t, NextSmaller(items), bruteNearest(items, 1, smaller))
+			validateSlices(t, PreviousGreater(items), bruteNearest(items, -1, greater))
+			validateSlices(t, PreviousSmaller(items), bruteNearest(items, -1, smaller))
+		}
+	})
+}
+
+func bruteLargestRectangle(heights []int) int {
+	largest := 0
+
+	for i := range heights {
+		low := heights[i]
+		for j := i; j < len(heights); j++ {
+			low = min(low, heights[j])
+			largest = max(largest, low*(j-i+1))
+		}
+	}
+
+	return largest
+}
+
+func TestLargestRectangle(t *testing.T) {
+	utils.ValidateResult(t, LargestRectangle([]int{2, 1, 5, 6, 2, 3}), 10)
+	utils.ValidateResult(t, LargestRectangle([]float64{1.5, 2, 2}), 4.5)
+	utils.ValidateResult(t, LargestRectangle([]uint{}), uint(0))
+
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < trials; i++ {
+		heights := randomInts(r)
+		utils.ValidateResult(t, LargestRectangle(heights), bruteLargestRectangle(heights))
+	}
+}
+
+func bruteMaximalRectangle(matrix [][]bool) int {
+	largest := 0
+
+	for top := range matrix {
+		for left := range matrix[top] {
+			for bottom := top; bottom < len(matrix); bottom++ {
+				for right := left; right < len(matrix[top]); right++ {
+					full := true
+					for i := top; i <= bottom && full; i++ {
+						for j := left; j <= right && full; j++ {
+							full = matrix[i][j]
+						}
+					}
+
+					if full {
+						largest = max(largest, (bottom-top+1)*(right-left+1))
+					}
+				}
+			}
+		}
+	}
+
+	return largest
+}
+
+func TestMaximalRectangle(t *testing.T) {
+	utils.ValidateResult(t, MaximalRectangle(nil), 0)
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < trials; i++ {
+		matrix := make([][]bool, r.Intn(6))
+		cols := r.Intn(6)
+		for row := range matrix {
+			matrix[row] = make([]bool, cols)
+			for col := range matrix[row] {
+				matrix[row][col] = r.Intn(4) > 0
+			}
+		}
+
+		utils.ValidateResult(t, MaximalRectangle(matrix), bruteMaximalRectangle(matrix))
+	}
+}
+
+func TestStockSpan(t *testing.T) {
+	validateSlices(t, StockSpan([]int{100, 80, 60, 70, 60, 75, 85}), []int{1, 1, 1, 2, 1, 4, 6})
+
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < trials; i++ {
+		prices := randomInts(r)
+
+		want := make([]int, len(prices))
+		for day := range prices {
+			for want[day] = 1; day-want[day] >= 0 && prices[day-want[day]] <= prices[day]; want[day]++ {
+			}
+		}
+
+		validateSlices(t, StockSpan(prices), want)
+	}
+}
+
+func TestTrapRainWater(t *testing.T) {
+	utils.ValidateResult(t, TrapRainWater([]int{0, 1, 0, 2, 1, 0, 1, 3, 2, 1, 2, 1}), 6)
+	utils.ValidateResult(t, TrapRainWater([]uint8{3, 0, 3}), uint8(3))
+
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < trials; i++ {
+		heights := randomInts(r)
+
+		want := 0
+		for j := range heights {
+			left, right := 0, 0
+			for _, h := range heights[:j+1] {
+				left = max(left, h)
+			}
+			for _, h := range heights[j:] {
+				right = max(right, h)
+			}
+
+			want += min(left, right) - heights[j]
+		}
+
+		utils.ValidateResult(t, TrapRainWater(heights), want)
+	}
+}
+
+func TestSumSubarrayMinimums(t *testing.T) {
+	utils.ValidateResult(t, SumSubarrayMinimums([]int{3, 1, 2, 4}), 17)
+
+	r := rand.New(rand.NewSource(6))
+	for i := 0; i < trials; i++ {
+		items := randomInts(r)
+
+		want := 0
+		for start := range items {
+			low := items[start]
+			for end := start; end < len(items); end++ {
+				low = min(low, items[end])
+				want += low
+			}
+		}
+
+		utils.ValidateResult(t, SumSubarrayMinimums(items), want)
+	}
+}
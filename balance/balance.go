@@ -0,0 +1,341 @@
+// Package balance checks that the delimiters of a text are balanced.
+//
+// The text is streamed from an io.Reader, so inputs of any size are checked in
+// constant memory apart from the stack of open delimiters. Delimiters can be
+// any non-empty strings; where several match at the same position the longest
+// wins, so with the pairs {} and {{ }} the text "{{" opens one {{ rather than
+// two {. Inside regions such as string literals and comments delimiters are
+// ignored.
+package balance
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+// Pair is an opening delimiter and the closer that balances it. Open and
+// Close may be the same string, in which case it closes the pair if the pair
+// is open and opens it otherwise.
+type Pair struct {
+	Open, Close string
+}
+
+// Region is a stretch of text in which delimiters are ignored, such as a
+// string literal or a comment. A non-zero Escape makes the byte after it part
+// of the region even if it would otherwise end it. A region whose End is "\n"
+// is also ended by the end of the input, as line comments are.
+type Region struct {
+	Start, End string
+	Escape     byte
+}
+
+var DefaultPairs = []Pair{{"(", ")"}, {"[", "]"}, {"{", "}"}}
+
+type Kind int
+
+const (
+	// Unclosed is an opener, or the start of a region, that is never closed.
+	Unclosed Kind = iota
+	// Unexpected is a closer that no open delimiter matches.
+	Unexpected
+)
+
+// Mismatch is a delimiter that does not balance. Line and Column locate it,
+// both counted from 1 with columns counted in runes. Expected is the closer
+// that would balance the innermost open delimiter at that point, or empty if
+// none was open.
+type Mismatch struct {
+	Kind         Kind
+	Delimiter    string
+	Expected     string
+	Line, Column int
+}
+
+func (m Mismatch) String() string {
+	var msg string
+	if m.Kind == Unclosed {
+		msg = fmt.Sprintf("unclosed %s", m.Delimiter)
+	} else {
+		msg = fmt.Sprintf("unexpected %s", m.Delimiter)
+	}
+
+	if m.Expected != "" {
+		msg += fmt.Sprintf(", expected %s", m.Expected)
+	}
+
+	return fmt.Sprintf("%d:%d: %s", m.Line, m.Column, msg)
+}
+
+// Insertion is a suggested fix: inserting Text before the character at Line
+// and Column, or at the end of the input if that is where they point.
+type Insertion struct {
+	Text         string
+	Line, Column int
+}
+
+type config struct {
+	pairs   []Pair
+	regions []Region
+}
+
+type Option func(*config)
+
+// WithPairs replaces DefaultPairs with pairs.
+func WithPairs(pairs ...Pair) Option {
+	return func(c *config) { c.pairs = pairs }
+}
+
+func WithRegions(regions ...Region) Option {
+	return func(c *config) { c.regions = regions }
+}
+
+// delimiter records every role a string plays, as an index into the pairs or
+// regions, or -1.
+type delimiter struct {
+	text   string
+	opens  int
+	closes int
+	starts int
+}
+
+type Checker struct {
+	pairs      []Pair
+	regions    []Region
+	delimiters []*delimiter
+	longest    int
+}
+
+var errEmptyDelimiter = errors.New("balance: delimiters must not be empty")
+
+// New creates a checker. It panics if a pair or region has an empty
+// delimiter.
+func New(opts ...Option) *Checker {
+	c := config{pairs: DefaultPairs}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	checker := &Checker{pairs: c.pairs, regions: c.regions}
+	byText := make(map[string]*delimiter)
+
+	role := func(text string) *delimiter {
+		if text == "" {
+			panic(errEmptyDelimiter)
+		}
+
+		d, ok := byText[text]
+		if !ok {
+			d = &delimiter{text: text, opens: -1, closes: -1, starts: -1}
+			byText[text] = d
+			checker.delimiters = append(checker.delimiters, d)
+			checker.longest = max(checker.longest, len(text))
+		}
+
+		return d
+	}
+
+	for i, p := range c.pairs {
+		role(p.Open).opens = i
+		role(p.Close).closes = i
+	}
+
+	for i, r := range c.regions {
+		role(r.Start).starts = i
+
+		if r.End == "" {
+			panic(errEmptyDelimiter)
+		}
+		checker.longest = max(checker.longest, len(r.End))
+	}
+
+	return checker
+}
+
+// Check reports every mismatch in the text read from r, in the order they
+// are found. Closers that match an open delimiter further down are taken to
+// close it, and the delimiters opened above it are reported as unclosed.
+func (c *Checker) Check(r io.Reader) ([]Mismatch, error) {
+	s := c.scan(r)
+	return s.mismatches, s.err
+}
+
+type open struct {
+	pair         int
+	line, column int
+}
+
+type scanState struct {
+	checker    *Checker
+	in         *bufio.Reader
+	open       *stack.Stack[open]
+	openCount  []int
+	at         position
+	mismatches []Mismatch
+	// tokens and regionEnd are what Suggest works from: every delimiter
+	// outside a region, and the end of a region left open by the input.
+	tokens    []token
+	regionEnd string
+	err       error
+}
+
+func (c *Checker) scan(r io.Reader) *scanState {
+	s := &scanState{
+		checker:   c,
+		in:        bufio.NewReaderSize(r, max(c.longest, 4096)),
+		open:      stack.NewOf[open](),
+		openCount: make([]int, len(c.pairs)),
+		at:        position{line: 1, column: 1},
+	}
+
+	for {
+		buf, err := s.in.Peek(c.longest)
+		if len(buf) == 0 {
+			if err != io.EOF {
+				s.err = err
+			}
+			break
+		}
+
+		d := c.match(buf)
+		if d == nil {
+			s.advance(1)
+			continue
+		}
+
+		if top, ok := s.open.TryPeek(); ok && d.closes == top.pair {
+			s.close(d)
+		} else if d.starts >= 0 {
+			if !s.skipRegion(c.regions[d.starts]) {
+				break
+			}
+		} else if d.opens >= 0 && !(d.opens == d.closes && s.openCount[d.closes] > 0) {
+			s.push(d)
+		} else {
+			s.close(d)
+		}
+	}
+
+	for {
+		top, err := s.open.Pop()
+		if err != nil {
+			break
+		}
+
+		s.unclosed(top)
+	}
+
+	return s
+}
+
+// match returns the longest delimiter that buf starts with, or nil.
+func (c *Checker) match(buf []byte) *delimiter {
+	var longest *delimiter
+
+	for _, d := range c.delimiters {
+		if bytes.HasPrefix(buf, []byte(d.text)) && (longest == nil || len(d.text) > len(longest.text)) {
+			longest = d
+		}
+	}
+
+	return longest
+}
+
+func (s *scanState) push(d *delimiter) {
+	s.open.Push(open{d.opens, s.at.line, s.at.column})
+	s.openCount[d.opens]++
+	s.consume(d)
+}
+
+// close handles a closer. If the pair it closes is open further down, the
+// delimiters above it are unclosed. Otherwise the closer is stray.
+func (s *scanState) close(d *delimiter) {
+	if s.openCount[d.closes] == 0 {
+		expected := ""
+		if top, ok := s.open.TryPeek(); ok {
+			expected = s.checker.pairs[top.pair].Close
+		}
+
+		s.mismatches = append(s.mismatches, Mismatch{Unexpected, d.text, expected, s.at.line, s.at.column})
+		s.consume(d)
+		return
+	}
+
+	for {
+		top, _ := s.open.Pop()
+		s.openCount[top.pair]--
+
+		if top.pair == d.closes {
+			break
+		}
+
+		s.unclosed(top)
+	}
+
+	s.consume(d)
+}
+
+func (s *scanState) unclosed(o open) {
+	p := s.checker.pairs[o.pair]
+	s.mismatches = append(s.mismatches, Mismatch{Unclosed, p.Open, p.Close, o.line, o.column})
+}
+
+// consume advances past the delimiter d at the current position, recording
+// it for Suggest.
+func (s *scanState) consume(d *delimiter) {
+	start := s.at
+	s.advance(len(d.text))
+	s.tokens = append(s.tokens, token{d, start, s.at})
+}
+
+// skipRegion advances past the region starting at the current position. It
+// returns false if the input ends first.
+func (s *scanState) skipRegion(r Region) bool {
+	line, column := s.at.line, s.at.column
+	s.advance(len(r.Start))
+
+	for {
+		buf, err := s.in.Peek(len(r.End))
+
+		switch {
+		case bytes.HasPrefix(buf, []byte(r.End)):
+			s.advance(len(r.End))
+			return true
+		case len(buf) == 0:
+			if err != io.EOF {
+				s.err = err
+			} else if r.End != "\n" {
+				s.mismatches = append(s.mismatches, Mismatch{Unclosed, r.Start, r.End, line, column})
+				s.regionEnd = r.End
+			}
+			return false
+		case r.Escape != 0 && buf[0] == r.Escape:
+			s.advance(2)
+		default:
+			s.advance(1)
+		}
+	}
+}
+
+// advance consumes up to n bytes, keeping track of the line and column.
+// Continuation bytes of UTF-8 sequences do not count as columns.
+func (s *scanState) advance(n int) {
+	buf, _ := s.in.Peek(n)
+
+	for _, b := range buf {
+		switch {
+		case b == '\n':
+			s.at.line++
+			s.at.column = 1
+		case b&0xC0 != 0x80:
+			s.at.column++
+		}
+	}
+
+	s.at.offset += len(buf)
+	s.in.Discard(len(buf))
+}
@@ -0,0 +1,223 @@
+package balance
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var template = New(
+	WithPairs(append([]Pair{{"{{", "}}"}, {"<%", "%>"}}, DefaultPairs...)...),
+	WithRegions(Region{Start: `"`, End: `"`, Escape: '\\'}, Region{Start: "#", End: "\n"}),
+)
+
+func check(t *testing.T, c *Checker, text string) []string {
+	t.Helper()
+
+	mismatches, err := c.Check(strings.NewReader(text))
+	utils.ValidateResult(t, err, nil)
+
+	got := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		got[i] = m.String()
+	}
+
+	return got
+}
+
+func validateMismatches(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	utils.ValidateResult(t, strings.Join(got, "\n"), strings.Join(want, "\n"))
+}
+
+// fix applies insertions to text, which is how a caller would use Suggest.
+func fix(text string, insertions []Insertion) string {
+	var b strings.Builder
+	line, column := 1, 1
+	next := 0
+
+	emit := func() {
+		for next < len(insertions) && insertions[next].Line == line && insertions[next].Column == column {
+			b.WriteString(insertions[next].Text)
+			next++
+		}
+	}
+
+	for _, r := range text {
+		emit()
+		b.WriteRune(r)
+
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+
+	emit()
+	return b.String()
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("Accepts balanced text", func(t *testing.T) {
+		validateMismatches(t, check(t, New(), "f(a[1], {b: (2)})"))
+	})
+
+	t.Run("Reports an unclosed opener with its closer", func(t *testing.T) {
+		validateMismatches(t, check(t, New(), "a\n  (b[c]"), "2:3: unclosed (, expected )")
+	})
+
+	t.Run("Reports a stray closer with the closer expected instead", func(t *testing.T) {
+		validateMismatches(t, check(t, New(), "(a]b)"), "1:3: unexpected ], expected )")
+		validateMismatches(t, check(t, New(), "a)"), "1:2: unexpected )")
+	})
+
+	t.Run("Closes a deeper opener and reports the ones above it", func(t *testing.T) {
+		validateMismatches(t, check(t, New(), "{ ( [ }"),
+			"1:5: unclosed [, expected ]",
+			"1:3: unclosed (, expected )",
+		)
+	})
+
+	t.Run("Counts columns in runes", func(t *testing.T) {
+		validateMismatches(t, check(t, New(), "héllo ]"), "1:7: unexpected ]")
+	})
+
+	t.Run("Prefers the longest delimiter", func(t *testing.T) {
+		validateMismatches(t, check(t, template, "{{ x }} <% if (y) %> {a}"))
+		validateMismatches(t, check(t, template, "{{ x }"),
+			"1:6: unexpected }, expected }}",
+			"1:1: unclosed {{, expected }}",
+		)
+	})
+
+	t.Run("Ignores delimiters in regions", func(t *testing.T) {
+		validateMismatches(t, check(t, template, `{{ "}}\" ((" }} # ) ]`+"\n()"))
+	})
+
+	t.Run("Reports an unterminated region", func(t *testing.T) {
+		validateMismatches(t, check(t, template, `( "abc`),
+			`1:3: unclosed ", expected "`,
+			"1:1: unclosed (, expected )",
+		)
+	})
+
+	t.Run("Lets a line comment run to the end of the input", func(t *testing.T) {
+		validateMismatches(t, check(t, template, "() # (("))
+	})
+
+	t.Run("Toggles a pair whose delimiters are the same", func(t *testing.T) {
+		c := New(WithPairs(Pair{"|", "|"}, Pair{"(", ")"}))
+
+		validateMismatches(t, check(t, c, "|(a)| |b|"))
+		validateMismatches(t, check(t, c, "|(|)"), "1:2: unclosed (, expected )", "1:4: unexpected )")
+	})
+
+	t.Run("Streams input larger than its buffer", func(t *testing.T) {
+		text := strings.Repeat("(", 10000) + strings.Repeat(")", 10000)
+		validateMismatches(t, check(t, New(), text))
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	tests := map[string]string{
+		"(a":          "(a)",
+		"a)":          "a()",
+		"(a]":         "(a[])",
+		"{ ( [ }":     "{ ( [ ])}",
+		"{{ x":        "{{ x}}",
+		`( "abc`:      `( "abc")`,
+		"<% (y %>":    "<% (y )%>",
+		"[(a) b":      "[(a) b]",
+		"balanced!":   "balanced!",
+		"[ ( ( ] ) )": "[ ( ( [] ) )]",
+		"{{ }":        "{{}} {}",
+		"{{}":         "{{}}{}",
+		"{}}":         "{}{{}}",
+		"( # c":       "() # c",
+	}
+
+	for text, want := range tests {
+		insertions, err := template.Suggest(strings.NewReader(text))
+		utils.ValidateResult(t, err, nil)
+
+		got := fix(text, insertions)
+		utils.ValidateResult(t, got, want)
+		validateMismatches(t, check(t, template, got))
+	}
+}
+
+// fewestInsertions is the number of delimiters that balance text, where every
+// byte of it is one of the DefaultPairs, found by trying every way to match
+// up its first delimiter.
+func fewestInsertions(text string) int {
+	memo := make(map[[2]int]int)
+
+	var fewest func(i, j int) int
+	fewest = func(i, j int) int {
+		if i >= j {
+			return 0
+		}
+		if n, ok := memo[[2]int{i, j}]; ok {
+			return n
+		}
+
+		n := 1 + fewest(i+1, j)
+		for k := i + 1; k < j; k++ {
+			if strings.IndexByte("([{", text[i]) >= 0 && strings.IndexByte(")]}", text[k]) == strings.IndexByte("([{", text[i]) {
+				n = min(n, fewest(i+1, k)+fewest(k+1, j))
+			}
+		}
+
+		memo[[2]int{i, j}] = n
+		return n
+	}
+
+	return fewest(0, len(text))
+}
+
+func TestSuggestIsMinimal(t *testing.T) {
+	c := New()
+	r := rand.New(rand.NewSource(1))
+
+	for range 2000 {
+		b := make([]byte, r.Intn(12))
+		for i := range b {
+			b[i] = "()[]{}"[r.Intn(6)]
+		}
+		text := string(b)
+
+		insertions, err := c.Suggest(strings.NewReader(text))
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, len(insertions), fewestInsertions(text))
+		validateMismatches(t, check(t, c, fix(text, insertions)))
+	}
+
+	t.Run("Counts delimiters that overlap as they are read", func(t *testing.T) {
+		tests := map[string]int{
+			"{{ }":    2,
+			"{{ { }}": 1,
+			"}}{{":    2,
+			"[(])":    2,
+		}
+
+		for text, want := range tests {
+			insertions, err := template.Suggest(strings.NewReader(text))
+			utils.ValidateResult(t, err, nil)
+			utils.ValidateResult(t, len(insertions), want)
+		}
+	})
+}
+
+func TestNewPanicsOnEmptyDelimiter(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected New to panic but it did not")
+		}
+	}()
+
+	New(WithPairs(Pair{"", ")"}))
+}
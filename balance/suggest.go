@@ -0,0 +1,252 @@
+package balance
+
+import (
+	"bytes"
+	"io"
+	"slices"
+)
+
+// position is a place in the input, both as a byte offset and as the line
+// and column an Insertion reports.
+type position struct {
+	offset       int
+	line, column int
+}
+
+// token is a delimiter found outside any region, with where it starts and
+// ends.
+type token struct {
+	d          *delimiter
+	start, end position
+}
+
+// balancedBy reports whether b closes the pair that t opens.
+func (t token) balancedBy(b token) bool {
+	return t.d.opens >= 0 && t.d.opens == b.d.closes
+}
+
+// placed is an insertion at its final position. Regions left open are closed
+// by text that is not a delimiter of its own.
+type placed struct {
+	text   string
+	at     position
+	region bool
+}
+
+// Suggest proposes insertions that balance the text read from r. Every
+// delimiter in the text is kept as Check reads it, and no fix that does so
+// needs fewer insertions. The opener of a stray closer goes right before it,
+// and the closer of an unclosed delimiter goes where it was found to be
+// missing, or earlier if it would run into the text there.
+//
+// Unlike Check, Suggest reads the whole input into memory. Pairs that are
+// adjacent once inner pairs are removed are matched as they are found; the
+// delimiters left over cost time cubic in their number.
+func (c *Checker) Suggest(r io.Reader) ([]Insertion, error) {
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := c.scan(bytes.NewReader(text))
+
+	var insertions []placed
+	if s.regionEnd != "" {
+		insertions = append(insertions, placed{s.regionEnd, s.at, true})
+	}
+
+	insertions = c.fix(text, s.tokens, s.at, insertions)
+
+	suggested := make([]Insertion, len(insertions))
+	for i, in := range insertions {
+		suggested[i] = Insertion{in.text, in.at.line, in.at.column}
+	}
+
+	return suggested, nil
+}
+
+// fix adds to insertions the delimiters that balance tokens, in the order
+// they appear in the fixed text. Tokens that cancel against a neighbour are
+// matched as they are found, and the rest are matched up by finding the
+// fewest of them left unmatched, fewest[i][j], in every stretch i..j-1.
+func (c *Checker) fix(text []byte, tokens []token, end position, insertions []placed) []placed {
+	var rest []int
+	for i, t := range tokens {
+		if n := len(rest); n > 0 && tokens[rest[n-1]].balancedBy(t) {
+			rest = rest[:n-1]
+		} else {
+			rest = append(rest, i)
+		}
+	}
+
+	n := len(rest)
+	fewest := make([][]int, n+1)
+	match := make([][]int, n+1)
+	for i := range fewest {
+		fewest[i] = make([]int, n+1)
+		match[i] = make([]int, n+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j <= n; j++ {
+			fewest[i][j], match[i][j] = 1+fewest[i+1][j], -1
+
+			for k := i + 1; k < j; k++ {
+				if !tokens[rest[i]].balancedBy(tokens[rest[k]]) {
+					continue
+				}
+
+				if cost := fewest[i+1][k] + fewest[k+1][j]; cost < fewest[i][j] {
+					fewest[i][j], match[i][j] = cost, k
+				}
+			}
+		}
+	}
+
+	// before returns the position right before the k-th leftover token, or
+	// the end of the input, and the end of the token preceding that. Either
+	// is a place for the delimiter inserted there.
+	before := func(k int) []position {
+		next, late := len(tokens), end
+		if k < n {
+			next = rest[k]
+			late = tokens[next].start
+		}
+
+		early := position{line: 1, column: 1}
+		if next > 0 {
+			early = tokens[next-1].end
+		}
+
+		return []position{late, early}
+	}
+
+	// try makes the insertion if the fixed text still scans intact.
+	try := func(in placed, first bool) bool {
+		tried, ok := c.place(text, tokens, insertions, in, first)
+		if ok {
+			insertions = tried
+		}
+		return ok
+	}
+
+	// add makes the insertion at the first of the positions where it keeps
+	// the text intact, or at the first position if there is none.
+	add := func(delim string, at []position) {
+		for _, pos := range at {
+			if try(placed{text: delim, at: pos}, false) {
+				return
+			}
+		}
+
+		insertions = insert(insertions, placed{text: delim, at: at[0]}, false)
+	}
+
+	var build func(i, j int)
+	build = func(i, j int) {
+		if i >= j {
+			return
+		}
+
+		t := tokens[rest[i]]
+
+		switch k := match[i][j]; {
+		case k >= 0:
+			build(i+1, k)
+			build(k+1, j)
+		case t.d.opens >= 0:
+			build(i+1, j)
+
+			// A closer that runs into the text where it was missing can go
+			// right after its opener instead.
+			closer := c.pairs[t.d.opens].Close
+			at := before(j)
+			if !try(placed{text: closer, at: at[0]}, false) &&
+				!try(placed{text: closer, at: at[1]}, false) &&
+				!try(placed{text: closer, at: t.end}, true) {
+				add(closer, at)
+			}
+		default:
+			add(c.pairs[t.d.closes].Open, before(i))
+			build(i+1, j)
+		}
+	}
+	build(0, n)
+
+	return insertions
+}
+
+// insert adds in to a copy of the insertions, after those at the same
+// position, or before them if first is set.
+func insert(insertions []placed, in placed, first bool) []placed {
+	i := len(insertions)
+	for i > 0 && (insertions[i-1].at.offset > in.at.offset || first && insertions[i-1].at.offset == in.at.offset) {
+		i--
+	}
+
+	return slices.Insert(slices.Clone(insertions), i, in)
+}
+
+// place inserts in, and reports whether text with the insertions made still
+// scans intact. Unless in goes first, it may not go before a delimiter
+// already inserted, as those are made in the order they appear.
+func (c *Checker) place(text []byte, tokens []token, insertions []placed, in placed, first bool) ([]placed, bool) {
+	if !first {
+		for _, made := range insertions {
+			if !made.region && made.at.offset > in.at.offset {
+				return insertions, false
+			}
+		}
+	}
+
+	tried := insert(insertions, in, first)
+	return tried, c.intact(text, tokens, tried)
+}
+
+// intact reports whether text with the insertions made scans as tokens with
+// the inserted delimiters among them.
+func (c *Checker) intact(text []byte, tokens []token, insertions []placed) bool {
+	var fixed []byte
+	var want []*delimiter
+	from, next := 0, 0
+
+	for _, in := range insertions {
+		for ; next < len(tokens) && tokens[next].start.offset < in.at.offset; next++ {
+			want = append(want, tokens[next].d)
+		}
+		if !in.region {
+			want = append(want, c.find(in.text))
+		}
+
+		fixed = append(append(fixed, text[from:in.at.offset]...), in.text...)
+		from = in.at.offset
+	}
+
+	for ; next < len(tokens); next++ {
+		want = append(want, tokens[next].d)
+	}
+	fixed = append(fixed, text[from:]...)
+
+	got := c.scan(bytes.NewReader(fixed)).tokens
+	if len(got) != len(want) {
+		return false
+	}
+
+	for i, t := range got {
+		if t.d != want[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Checker) find(text string) *delimiter {
+	for _, d := range c.delimiters {
+		if d.text == text {
+			return d
+		}
+	}
+
+	return nil
+}
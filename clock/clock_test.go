@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fired(t Timer) bool {
+	select {
+	case <-t.C():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFake(t *testing.T) {
+	t.Run("Moves only when advanced", func(t *testing.T) {
+		c := NewFake(start)
+		utils.ValidateResult(t, c.Now(), start)
+
+		c.Advance(time.Minute)
+		utils.ValidateResult(t, c.Now(), start.Add(time.Minute))
+	})
+
+	t.Run("Fires timers once their deadline is reached", func(t *testing.T) {
+		c := NewFake(start)
+		timer := c.NewTimer(time.Second)
+
+		c.Advance(999 * time.Millisecond)
+		utils.ValidateResult(t, fired(timer), false)
+
+		c.Advance(time.Millisecond)
+		utils.ValidateResult(t, fired(timer), true)
+	})
+
+	t.Run("Fires timers without a delay at once", func(t *testing.T) {
+		utils.ValidateResult(t, fired(NewFake(start).NewTimer(0)), true)
+	})
+
+	t.Run("Does not fire stopped timers", func(t *testing.T) {
+		c := NewFake(start)
+		timer := c.NewTimer(time.Second)
+
+		utils.ValidateResult(t, timer.Stop(), true)
+		utils.ValidateResult(t, timer.Stop(), false)
+
+		c.Advance(time.Second)
+		utils.ValidateResult(t, fired(timer), false)
+	})
+}
+
+func TestReal(t *testing.T) {
+	var c Real
+	start := c.Now()
+	<-c.NewTimer(10 * time.Millisecond).C()
+
+	if elapsed := c.Now().Sub(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected the timer to fire after at least 10ms but it fired after %v", elapsed)
+	}
+}
@@ -0,0 +1,11 @@
+// Package constraints defines the type constraints shared by the generic
+// algorithms in this module.
+package constraints
+
+// Number is satisfied by every integer and floating-point type, so that
+// generic code can add, subtract, multiply and compare its values.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
@@ -0,0 +1,178 @@
+// Package deque implements a double-ended queue on an array of fixed-size
+// blocks, in the manner of C++'s std::deque. Items are addressed by a single
+// index into the concatenation of the blocks, so indexing is O(1), and growing
+// only copies block pointers, never the items themselves.
+package deque
+
+import (
+	"fmt"
+	"sync"
+)
+
+const blockSize = 64
+
+type Deque[T any] struct {
+	blocks [][]T
+	head   int
+	len    int
+	mu     sync.Mutex
+}
+
+func New[T any]() *Deque[T] {
+	return new(Deque[T])
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.head == 0 {
+		d.recenter()
+	}
+
+	d.head--
+	*d.slot(d.head) = item
+	d.len++
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.head+d.len == len(d.blocks)*blockSize {
+		d.recenter()
+	}
+
+	*d.slot(d.head + d.len) = item
+	d.len++
+}
+
+func (d *Deque[T]) PopFront() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var zero T
+
+	if d.len == 0 {
+		return zero, false
+	}
+
+	slot := d.slot(d.head)
+	item := *slot
+	*slot = zero
+	d.head++
+	d.len--
+
+	if d.head%blockSize == 0 {
+		d.blocks[d.head/blockSize-1] = nil
+	}
+
+	return item, true
+}
+
+func (d *Deque[T]) PopBack() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var zero T
+
+	if d.len == 0 {
+		return zero, false
+	}
+
+	index := d.head + d.len - 1
+	slot := d.slot(index)
+	item := *slot
+	*slot = zero
+	d.len--
+
+	if index%blockSize == 0 {
+		d.blocks[index/blockSize] = nil
+	}
+
+	return item, true
+}
+
+func (d *Deque[T]) Front() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return *d.slot(d.head), true
+}
+
+func (d *Deque[T]) Back() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return *d.slot(d.head + d.len - 1), true
+}
+
+// At returns the item at index counted from the front, which is index 0.
+func (d *Deque[T]) At(index int) (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if index < 0 || index >= d.len {
+		var zero T
+		return zero, fmt.Errorf("Index out of bounds: index %d provided but deque has length %d", index, d.len)
+	}
+
+	return *d.slot(d.head + index), nil
+}
+
+func (d *Deque[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.len
+}
+
+func (d *Deque[T]) IsEmpty() bool { return d.Len() == 0 }
+
+func (d *Deque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.blocks = nil
+	d.head = 0
+	d.len = 0
+}
+
+// slot returns the storage for the given index, allocating its block on first
+// use.
+func (d *Deque[T]) slot(index int) *T {
+	block := d.blocks[index/blockSize]
+
+	if block == nil {
+		block = make([]T, blockSize)
+		d.blocks[index/blockSize] = block
+	}
+
+	return &block[index%blockSize]
+}
+
+// recenter moves the blocks that hold items into the middle of a new block
+// map with free blocks on both sides. The map is sized relative to the blocks
+// in use, so pushes at either end recenter at most once per block's worth of
+// pushes on average.
+func (d *Deque[T]) recenter() {
+	first := d.head / blockSize
+	used := (d.head+d.len+blockSize-1)/blockSize - first
+	offset := used/2 + 1
+
+	blocks := make([][]T, 2*used+2)
+	copy(blocks[offset:], d.blocks[first:first+used])
+
+	d.blocks = blocks
+	d.head = offset*blockSize + d.head%blockSize
+}
@@ -0,0 +1,228 @@
+package deque
+
+import (
+	"math/rand"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []interface{}{1, "string", 0.4, "another string"}
+
+type testContext struct {
+	deque          *Deque[interface{}]
+	itemsLastIndex int
+}
+
+func (c *testContext) beforeEach() {
+	d := New[interface{}]()
+
+	for _, item := range items {
+		d.PushBack(item)
+	}
+
+	c.deque = d
+
+	c.itemsLastIndex = len(items) - 1
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestPushFront(t *testing.T) {
+	t.Run("Prepends items", testCase(func(t *testing.T, tc *testContext) {
+		tc.deque.PushFront("front")
+
+		got, _ := tc.deque.Front()
+		want := "front"
+		utils.ValidateResult(t, got, want)
+		utils.ValidateResult(t, tc.deque.Len(), len(items)+1)
+	}))
+
+	t.Run("Keeps order across several blocks", func(t *testing.T) {
+		d := New[int]()
+		for i := 0; i < 3*blockSize; i++ {
+			d.PushFront(i)
+		}
+
+		for i := 0; i < 3*blockSize; i++ {
+			got, _ := d.At(i)
+			utils.ValidateResult(t, got, 3*blockSize-1-i)
+		}
+	})
+}
+
+func TestPushBack(t *testing.T) {
+	t.Run("Appends items", testCase(func(t *testing.T, tc *testContext) {
+		for i := range items {
+			got, _ := tc.deque.At(i)
+			utils.ValidateResult(t, got, items[i])
+		}
+	}))
+
+	t.Run("Growing copies block pointers rather than items", func(t *testing.T) {
+		d := New[int]()
+		d.PushBack(0)
+		first := &d.blocks[d.head/blockSize][0]
+
+		for i := 1; i < 10*blockSize; i++ {
+			d.PushBack(i)
+		}
+
+		if &d.blocks[d.head/blockSize][0] != first {
+			t.Error("Expected the first block to be reused after growing but it was reallocated")
+		}
+	})
+}
+
+func TestPopFront(t *testing.T) {
+	t.Run("Returns items from the front", testCase(func(t *testing.T, tc *testContext) {
+		for i := range items {
+			got, ok := tc.deque.PopFront()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[i])
+		}
+
+		_, ok := tc.deque.PopFront()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Releases blocks it has emptied", func(t *testing.T) {
+		d := New[int]()
+		for i := 0; i < 2*blockSize; i++ {
+			d.PushBack(i)
+		}
+
+		emptied := d.head / blockSize
+		for i := 0; i < blockSize; i++ {
+			d.PopFront()
+		}
+
+		if d.blocks[emptied] != nil {
+			t.Error("Expected the emptied block to be released but it was not")
+		}
+	})
+}
+
+func TestPopBack(t *testing.T) {
+	t.Run("Returns items from the back", testCase(func(t *testing.T, tc *testContext) {
+		for i := range items {
+			got, ok := tc.deque.PopBack()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[tc.itemsLastIndex-i])
+		}
+
+		_, ok := tc.deque.PopBack()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Clears the vacated slot", testCase(func(t *testing.T, tc *testContext) {
+		index := tc.deque.head + tc.itemsLastIndex
+		tc.deque.PopBack()
+
+		got := tc.deque.blocks[index/blockSize][index%blockSize]
+		var want interface{} = nil
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestFrontAndBack(t *testing.T) {
+	t.Run("Return the ends without removing them", testCase(func(t *testing.T, tc *testContext) {
+		front, _ := tc.deque.Front()
+		back, _ := tc.deque.Back()
+
+		utils.ValidateResult(t, front, items[0])
+		utils.ValidateResult(t, back, items[tc.itemsLastIndex])
+		utils.ValidateResult(t, tc.deque.Len(), len(items))
+	}))
+
+	t.Run("Report an empty deque", func(t *testing.T) {
+		d := New[int]()
+		_, frontOk := d.Front()
+		_, backOk := d.Back()
+
+		utils.ValidateResult(t, frontOk, false)
+		utils.ValidateResult(t, backOk, false)
+	})
+}
+
+func TestAt(t *testing.T) {
+	t.Run("Throws error when negative bounds", testCase(func(t *testing.T, tc *testContext) {
+		if _, err := tc.deque.At(-1); err == nil {
+			t.Error("Expected negative bounds to throw error but it didn't")
+		}
+	}))
+
+	t.Run("Throws error when index exceeds upper bound", testCase(func(t *testing.T, tc *testContext) {
+		if _, err := tc.deque.At(len(items)); err == nil {
+			t.Error("Expected index exceeding upper bound to throw error but it didn't")
+		}
+	}))
+}
+
+func TestClear(t *testing.T) {
+	t.Run("Empties the deque", testCase(func(t *testing.T, tc *testContext) {
+		tc.deque.Clear()
+		utils.ValidateResult(t, tc.deque.IsEmpty(), true)
+
+		tc.deque.PushFront("foo")
+		got, _ := tc.deque.Back()
+		utils.ValidateResult(t, got, "foo")
+	}))
+}
+
+func TestMatchesSliceModel(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		d := New[int]()
+		var model []int
+
+		for i := 0; i < 5000; i++ {
+			switch op := rng.Intn(6); {
+			case op < 2:
+				d.PushBack(i)
+				model = append(model, i)
+			case op < 4:
+				d.PushFront(i)
+				model = append([]int{i}, model...)
+			case op == 4:
+				got, ok := d.PopFront()
+				utils.ValidateResult(t, ok, len(model) > 0)
+				if len(model) > 0 {
+					utils.ValidateResult(t, got, model[0])
+					model = model[1:]
+				}
+			default:
+				got, ok := d.PopBack()
+				utils.ValidateResult(t, ok, len(model) > 0)
+				if len(model) > 0 {
+					utils.ValidateResult(t, got, model[len(model)-1])
+					model = model[:len(model)-1]
+				}
+			}
+
+			utils.ValidateResult(t, d.Len(), len(model))
+
+			if len(model) > 0 {
+				index := rng.Intn(len(model))
+				got, err := d.At(index)
+				if err != nil {
+					t.Fatalf("Could not get item: %s", err.Error())
+				}
+				utils.ValidateResult(t, got, model[index])
+			}
+		}
+
+		for i := range model {
+			got, _ := d.At(i)
+			if got != model[i] {
+				t.Fatalf("seed %d: index %d: got: %v, want: %v", seed, i, got, model[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Package worksteal implements the Chase–Lev work-stealing deque.
+//
+// A single owner pushes and pops at the bottom, in LIFO order, while any
+// number of thieves steal from the top, in FIFO order. The owner only contends
+// with thieves over the last remaining item, which both sides claim with a
+// compare-and-swap on top. The circular array grows by copying into one twice
+// the size; thieves still holding the old array read the same items from it,
+// and the garbage collector frees it once nobody does.
+package worksteal
+
+import "sync/atomic"
+
+const minCapacity = 32
+
+type array[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+func newArray[T any](capacity int64) *array[T] {
+	return &array[T]{slots: make([]atomic.Pointer[T], capacity), mask: capacity - 1}
+}
+
+func (a *array[T]) load(i int64) *T { return a.slots[i&a.mask].Load() }
+
+func (a *array[T]) store(i int64, item *T) { a.slots[i&a.mask].Store(item) }
+
+func (a *array[T]) grow(top, bottom int64) *array[T] {
+	grown := newArray[T](2 * int64(len(a.slots)))
+
+	for i := top; i < bottom; i++ {
+		grown.store(i, a.load(i))
+	}
+
+	return grown
+}
+
+type Deque[T any] struct {
+	top    atomic.Int64
+	bottom atomic.Int64
+	array  atomic.Pointer[array[T]]
+}
+
+func New[T any]() *Deque[T] {
+	d := new(Deque[T])
+	d.array.Store(newArray[T](minCapacity))
+	return d
+}
+
+// Push adds item at the bottom. Only the owner may call it.
+func (d *Deque[T]) Push(item T) {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	a := d.array.Load()
+
+	if b-t >= int64(len(a.slots)) {
+		a = a.grow(t, b)
+		d.array.Store(a)
+	}
+
+	a.store(b, &item)
+	d.bottom.Store(b + 1)
+}
+
+// Pop removes the item at the bottom. Only the owner may call it.
+func (d *Deque[T]) Pop() (T, bool) {
+	var zero T
+
+	b := d.bottom.Load() - 1
+	a := d.array.Load()
+	d.bottom.Store(b)
+	t := d.top.Load()
+
+	if t > b {
+		d.bottom.Store(b + 1)
+		return zero, false
+	}
+
+	item := a.load(b)
+
+	if t == b {
+		won := d.top.CompareAndSwap(t, t+1)
+		d.bottom.Store(b + 1)
+
+		if !won {
+			return zero, false
+		}
+	} else {
+		a.store(b, nil)
+	}
+
+	return *item, true
+}
+
+// Steal removes the item at the top. Any goroutine may call it. It retries
+// while it loses races to other thieves and only fails when the deque is
+// empty.
+func (d *Deque[T]) Steal() (T, bool) {
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+
+		if t >= b {
+			var zero T
+			return zero, false
+		}
+
+		item := d.array.Load().load(t)
+
+		if d.top.CompareAndSwap(t, t+1) {
+			return *item, true
+		}
+	}
+}
+
+// Len is exact when called by the owner and approximate for anyone else.
+func (d *Deque[T]) Len() int {
+	return int(max(d.bottom.Load()-d.top.Load(), 0))
+}
+
+func (d *Deque[T]) IsEmpty() bool { return d.Len() == 0 }
@@ -0,0 +1,142 @@
+package worksteal
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []interface{}{1, "string", 0.4, "another string"}
+
+type testContext struct {
+	deque          *Deque[interface{}]
+	itemsLastIndex int
+}
+
+func (c *testContext) beforeEach() {
+	d := New[interface{}]()
+
+	for _, item := range items {
+		d.Push(item)
+	}
+
+	c.deque = d
+
+	c.itemsLastIndex = len(items) - 1
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestPop(t *testing.T) {
+	t.Run("Returns items in LIFO order", testCase(func(t *testing.T, tc *testContext) {
+		for i := range items {
+			got, ok := tc.deque.Pop()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[tc.itemsLastIndex-i])
+		}
+
+		_, ok := tc.deque.Pop()
+		utils.ValidateResult(t, ok, false)
+		utils.ValidateResult(t, tc.deque.Len(), 0)
+	}))
+}
+
+func TestSteal(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, tc *testContext) {
+		for i := range items {
+			got, ok := tc.deque.Steal()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[i])
+		}
+
+		_, ok := tc.deque.Steal()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Shares the deque with the owner", testCase(func(t *testing.T, tc *testContext) {
+		stolen, _ := tc.deque.Steal()
+		popped, _ := tc.deque.Pop()
+
+		utils.ValidateResult(t, stolen, items[0])
+		utils.ValidateResult(t, popped, items[tc.itemsLastIndex])
+		utils.ValidateResult(t, tc.deque.Len(), len(items)-2)
+	}))
+}
+
+func TestPush(t *testing.T) {
+	t.Run("Grows past the initial capacity", func(t *testing.T) {
+		d := New[int]()
+		for i := 0; i < 10*minCapacity; i++ {
+			d.Push(i)
+		}
+
+		for i := 0; i < 5*minCapacity; i++ {
+			got, _ := d.Steal()
+			utils.ValidateResult(t, got, i)
+		}
+
+		for i := 10*minCapacity - 1; i >= 5*minCapacity; i-- {
+			got, _ := d.Pop()
+			utils.ValidateResult(t, got, i)
+		}
+	})
+}
+
+func TestConcurrentSteal(t *testing.T) {
+	const n, thieves = 100000, 8
+
+	d := New[int]()
+	var taken [n]atomic.Int32
+	var stop atomic.Bool
+
+	var wg sync.WaitGroup
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				if item, ok := d.Steal(); ok {
+					taken[item].Add(1)
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		d.Push(i)
+
+		if i%3 == 0 {
+			if item, ok := d.Pop(); ok {
+				taken[item].Add(1)
+			}
+		}
+	}
+
+	for {
+		item, ok := d.Pop()
+		if !ok {
+			break
+		}
+		taken[item].Add(1)
+	}
+
+	stop.Store(true)
+	wg.Wait()
+
+	for i := range taken {
+		if count := taken[i].Load(); count != 1 {
+			t.Fatalf("Expected item %d to be taken once but it was taken %d times", i, count)
+		}
+	}
+}
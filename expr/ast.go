@@ -0,0 +1,122 @@
+package expr
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+// Node is a node of an expression's abstract syntax tree. String renders it
+// as infix, parenthesizing only where precedence or associativity demands.
+type Node interface {
+	String() string
+	precedence() int
+}
+
+type NumberNode struct {
+	Value float64
+}
+
+type VariableNode struct {
+	Name string
+}
+
+type NegateNode struct {
+	X Node
+}
+
+type BinaryNode struct {
+	Op   string
+	X, Y Node
+}
+
+type CallNode struct {
+	Name string
+	Args []Node
+}
+
+// Atoms bind tighter than any operator.
+const atomPrecedence = 5
+
+func (n *NumberNode) precedence() int   { return atomPrecedence }
+func (n *VariableNode) precedence() int { return atomPrecedence }
+func (n *NegateNode) precedence() int   { return negatePrecedence }
+func (n *BinaryNode) precedence() int   { return precedence(Token{Kind: Operator, Text: n.Op}) }
+func (n *CallNode) precedence() int     { return atomPrecedence }
+
+func (n *NumberNode) String() string {
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+func (n *VariableNode) String() string { return n.Name }
+
+func (n *NegateNode) String() string {
+	return "-" + wrap(n.X, n.X.precedence() < negatePrecedence)
+}
+
+func (n *BinaryNode) String() string {
+	prec := n.precedence()
+	right := n.Op == "^"
+
+	x := wrap(n.X, n.X.precedence() < prec || n.X.precedence() == prec && right)
+	y := wrap(n.Y, n.Y.precedence() < prec || n.Y.precedence() == prec && !right)
+
+	return x + " " + n.Op + " " + y
+}
+
+func (n *CallNode) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func wrap(n Node, parens bool) string {
+	if parens {
+		return "(" + n.String() + ")"
+	}
+
+	return n.String()
+}
+
+// Parse builds the abstract syntax tree of src.
+func Parse(src string) (Node, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	rpn, err := ToRPN(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := stack.NewOf[Node]()
+
+	for _, tok := range rpn {
+		switch tok.Kind {
+		case Number:
+			nodes.Push(&NumberNode{tok.Value})
+		case Ident:
+			nodes.Push(&VariableNode{tok.Text})
+		case Negate:
+			x, _ := nodes.Pop()
+			nodes.Push(&NegateNode{x})
+		case Operator:
+			operands := nodes.PopN(2)
+			nodes.Push(&BinaryNode{tok.Text, operands[1], operands[0]})
+		case Call:
+			args := nodes.PopN(tok.Args)
+			slices.Reverse(args)
+
+			nodes.Push(&CallNode{tok.Text, args})
+		}
+	}
+
+	root, _ := nodes.Pop()
+	return root, nil
+}
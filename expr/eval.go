@@ -0,0 +1,152 @@
+package expr
+
+import (
+	"math"
+	"slices"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+// Variadic is the arity of a function that takes any number of arguments.
+const Variadic = -1
+
+type function struct {
+	arity int
+	fn    func(args ...float64) (float64, error)
+}
+
+// Env holds the variables and functions an expression can refer to.
+type Env struct {
+	vars  map[string]float64
+	funcs map[string]function
+}
+
+func NewEnv() *Env {
+	return &Env{vars: make(map[string]float64), funcs: make(map[string]function)}
+}
+
+func (e *Env) Set(name string, value float64) {
+	e.vars[name] = value
+}
+
+// Register makes fn callable under name with exactly arity arguments, or with
+// at least one if arity is Variadic. An error returned by fn is reported at
+// the column of the call.
+func (e *Env) Register(name string, arity int, fn func(args ...float64) (float64, error)) {
+	e.funcs[name] = function{arity, fn}
+}
+
+// Eval evaluates src against env, which may be nil if src uses no names.
+func Eval(src string, env *Env) (float64, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return 0, err
+	}
+
+	rpn, err := ToRPN(tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	return EvalRPN(rpn, env)
+}
+
+// EvalRPN evaluates the output of ToRPN against env, which may be nil if the
+// expression uses no names.
+func EvalRPN(rpn []Token, env *Env) (float64, error) {
+	if env == nil {
+		env = NewEnv()
+	}
+
+	operands := stack.NewOf[float64]()
+
+	for _, tok := range rpn {
+		switch tok.Kind {
+		case Number:
+			operands.Push(tok.Value)
+		case Ident:
+			value, ok := env.vars[tok.Text]
+			if !ok {
+				return 0, errorAt(tok.Column, "unknown variable %s", tok.Text)
+			}
+
+			operands.Push(value)
+		case Negate:
+			x, err := operands.Pop()
+			if err != nil {
+				return 0, errorAt(tok.Column, "missing operand for -")
+			}
+
+			operands.Push(-x)
+		case Operator:
+			args := operands.PopN(2)
+			if len(args) < 2 {
+				return 0, errorAt(tok.Column, "missing operand for %s", tok.Text)
+			}
+
+			result, err := apply(tok, args[1], args[0])
+			if err != nil {
+				return 0, err
+			}
+
+			operands.Push(result)
+		case Call:
+			f, ok := env.funcs[tok.Text]
+			if !ok {
+				return 0, errorAt(tok.Column, "unknown function %s", tok.Text)
+			}
+
+			if f.arity == Variadic && tok.Args == 0 || f.arity != Variadic && f.arity != tok.Args {
+				return 0, errorAt(tok.Column, "%s called with %d arguments", tok.Text, tok.Args)
+			}
+
+			args := operands.PopN(tok.Args)
+			if len(args) < tok.Args {
+				return 0, errorAt(tok.Column, "missing arguments for %s", tok.Text)
+			}
+
+			slices.Reverse(args)
+
+			result, err := f.fn(args...)
+			if err != nil {
+				return 0, errorAt(tok.Column, "%s: %v", tok.Text, err)
+			}
+
+			operands.Push(result)
+		default:
+			return 0, errorAt(tok.Column, "unexpected %s in RPN", tok.Text)
+		}
+	}
+
+	if operands.Len() != 1 {
+		return 0, errorAt(1, "malformed RPN leaves %d values", operands.Len())
+	}
+
+	result, _ := operands.Pop()
+	return result, nil
+}
+
+func apply(op Token, x, y float64) (float64, error) {
+	switch op.Text {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/", "%":
+		if y == 0 {
+			return 0, errorAt(op.Column, "division by zero")
+		}
+
+		if op.Text == "%" {
+			return math.Mod(x, y), nil
+		}
+
+		return x / y, nil
+	case "^":
+		return math.Pow(x, y), nil
+	default:
+		return 0, errorAt(op.Column, "unknown operator %s", op.Text)
+	}
+}
@@ -0,0 +1,175 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func testEnv() *Env {
+	env := NewEnv()
+	env.Set("x", 3)
+	env.Set("pi", math.Pi)
+
+	env.Register("max", Variadic, func(args ...float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	})
+
+	env.Register("sqrt", 1, func(args ...float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, errors.New("negative argument")
+		}
+		return math.Sqrt(args[0]), nil
+	})
+
+	env.Register("rand", 0, func(args ...float64) (float64, error) { return 4, nil })
+
+	return env
+}
+
+func TestTokenize(t *testing.T) {
+	t.Run("Records kinds, values and columns", func(t *testing.T) {
+		tokens, err := Tokenize("max(x, 1.5e2)")
+		utils.ValidateResult(t, err, nil)
+
+		want := []Token{
+			{Kind: Ident, Text: "max", Column: 1},
+			{Kind: LeftParen, Text: "(", Column: 4},
+			{Kind: Ident, Text: "x", Column: 5},
+			{Kind: Comma, Text: ",", Column: 6},
+			{Kind: Number, Text: "1.5e2", Value: 150, Column: 8},
+			{Kind: RightParen, Text: ")", Column: 13},
+		}
+
+		utils.ValidateResult(t, len(tokens), len(want))
+		for i := range want {
+			utils.ValidateResult(t, tokens[i], want[i])
+		}
+	})
+
+	t.Run("Reports the column of an unexpected character", func(t *testing.T) {
+		_, err := Tokenize("1 + $")
+		utils.ValidateResult(t, err.Error(), "column 5: unexpected character '$'")
+	})
+}
+
+func TestToRPN(t *testing.T) {
+	tests := map[string]string{
+		"1 + 2 * 3":     "1 2 3 * +",
+		"(1 + 2) * 3":   "1 2 + 3 *",
+		"8 - 4 - 2":     "8 4 - 2 -",
+		"2 ^ 3 ^ 2":     "2 3 2 ^ ^",
+		"-2 ^ 2":        "2 2 ^ -",
+		"max(1, x + 1)": "1 x 1 + max",
+	}
+
+	for src, want := range tests {
+		tokens, _ := Tokenize(src)
+		rpn, err := ToRPN(tokens)
+		utils.ValidateResult(t, err, nil)
+
+		got := ""
+		for i, tok := range rpn {
+			if i > 0 {
+				got += " "
+			}
+			got += tok.Text
+		}
+
+		utils.ValidateResult(t, got, want)
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := map[string]float64{
+		"1 + 2 * 3":            7,
+		"(1 + 2) * 3":          9,
+		"8 - 4 - 2":            2,
+		"2 ^ 3 ^ 2":            512,
+		"-2 ^ 2":               -4,
+		"(-2) ^ 2":             4,
+		"2 ^ -1":               0.5,
+		"--x":                  3,
+		"7 % 4 * -x":           -9,
+		"max(1, x, 2)":         3,
+		"sqrt(max(16, x)) + 1": 5,
+		"rand() * 2":           8,
+		"1.5e2 / 100":          1.5,
+	}
+
+	for src, want := range tests {
+		got, err := Eval(src, testEnv())
+		if err != nil {
+			t.Errorf("Evaluating %q failed: %v", src, err)
+			continue
+		}
+
+		utils.ValidateResult(t, got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := map[string]string{
+		"":             "column 1: unexpected end of expression",
+		"1 +":          "column 4: unexpected end of expression",
+		"1 2":          "column 3: unexpected number 2",
+		"* 2":          "column 1: expected an operand before *",
+		"(1 + 2":       "column 1: unmatched (",
+		"1 + 2)":       "column 6: unmatched )",
+		"()":           "column 2: expected an operand before )",
+		"1, 2":         "column 2: unexpected , outside a function call",
+		"max(1,)":      "column 7: expected an operand before )",
+		"y + 1":        "column 1: unknown variable y",
+		"nope(1)":      "column 1: unknown function nope",
+		"sqrt(1, 2)":   "column 1: sqrt called with 2 arguments",
+		"max()":        "column 1: max called with 0 arguments",
+		"1 + sqrt(-1)": "column 5: sqrt: negative argument",
+		"x / (x - 3)":  "column 3: division by zero",
+	}
+
+	for src, want := range tests {
+		_, err := Eval(src, testEnv())
+		if err == nil {
+			t.Errorf("Expected evaluating %q to fail but it succeeded", src)
+			continue
+		}
+
+		var exprErr *Error
+		utils.ValidateResult(t, errors.As(err, &exprErr), true)
+		utils.ValidateResult(t, err.Error(), want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"1 + 2 * 3":         "1 + 2 * 3",
+		"((1 + 2)) * 3":     "(1 + 2) * 3",
+		"8 - (4 - 2)":       "8 - (4 - 2)",
+		"(8 - 4) - 2":       "8 - 4 - 2",
+		"(2 ^ 3) ^ 2":       "(2 ^ 3) ^ 2",
+		"2 ^ (3 ^ 2)":       "2 ^ 3 ^ 2",
+		"-(2 ^ 2)":          "-2 ^ 2",
+		"(-2) ^ 2":          "(-2) ^ 2",
+		"-(x + 1)":          "-(x + 1)",
+		"max(1,(x),2*3)":    "max(1, x, 2 * 3)",
+		"rand()":            "rand()",
+		"x*(y/z)":           "x * (y / z)",
+		"1.5e2 + 0.25 % pi": "150 + 0.25 % pi",
+	}
+
+	for src, want := range tests {
+		root, err := Parse(src)
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, root.String(), want)
+
+		// Rendering must round-trip to an equivalent expression.
+		again, _ := Parse(root.String())
+		utils.ValidateResult(t, again.String(), want)
+	}
+}
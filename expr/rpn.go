@@ -0,0 +1,176 @@
+package expr
+
+import "github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+
+const negatePrecedence = 3
+
+func precedence(t Token) int {
+	if t.Kind == Negate {
+		return negatePrecedence
+	}
+
+	switch t.Text {
+	case "+", "-":
+		return 1
+	case "*", "/", "%":
+		return 2
+	default:
+		return 4
+	}
+}
+
+func rightAssociative(t Token) bool {
+	return t.Kind == Negate || t.Text == "^"
+}
+
+// ToRPN reorders tokens into reverse Polish notation, checking along the way
+// that operands and operators alternate and that parentheses match. An
+// identifier followed by a parenthesis becomes a Call carrying its argument
+// count, and a minus where an operand is expected becomes a Negate.
+func ToRPN(tokens []Token) ([]Token, error) {
+	rpn := make([]Token, 0, len(tokens))
+	ops := stack.NewOf[Token]()
+	// args holds, for every open parenthesis, how many arguments the call it
+	// belongs to has seen so far, or -1 if it only groups.
+	args := stack.NewOf[int]()
+	expectOperand := true
+
+	for i, tok := range tokens {
+		switch tok.Kind {
+		case Number:
+			if !expectOperand {
+				return nil, errorAt(tok.Column, "unexpected number %s", tok.Text)
+			}
+
+			rpn = append(rpn, tok)
+			expectOperand = false
+		case Ident:
+			if !expectOperand {
+				return nil, errorAt(tok.Column, "unexpected name %s", tok.Text)
+			}
+
+			if i+1 < len(tokens) && tokens[i+1].Kind == LeftParen {
+				tok.Kind = Call
+				ops.Push(tok)
+				continue
+			}
+
+			rpn = append(rpn, tok)
+			expectOperand = false
+		case Operator:
+			if expectOperand {
+				if tok.Text != "-" {
+					return nil, errorAt(tok.Column, "expected an operand before %s", tok.Text)
+				}
+
+				tok.Kind = Negate
+				ops.Push(tok)
+				continue
+			}
+
+			for {
+				top, ok := ops.TryPeek()
+				if !ok || top.Kind != Operator && top.Kind != Negate {
+					break
+				}
+
+				if precedence(top) < precedence(tok) || precedence(top) == precedence(tok) && rightAssociative(tok) {
+					break
+				}
+
+				ops.Pop()
+				rpn = append(rpn, top)
+			}
+
+			ops.Push(tok)
+			expectOperand = true
+		case LeftParen:
+			if !expectOperand {
+				return nil, errorAt(tok.Column, "unexpected (")
+			}
+
+			if i > 0 && tokens[i-1].Kind == Ident {
+				args.Push(0)
+			} else {
+				args.Push(-1)
+			}
+
+			ops.Push(tok)
+		case Comma:
+			n, ok := args.TryPeek()
+			if !ok || n < 0 {
+				return nil, errorAt(tok.Column, "unexpected , outside a function call")
+			}
+
+			if expectOperand {
+				return nil, errorAt(tok.Column, "expected an argument before ,")
+			}
+
+			rpn = popUntilParen(ops, rpn)
+			args.Pop()
+			args.Push(n + 1)
+			expectOperand = true
+		case RightParen:
+			n, err := args.Pop()
+			if err != nil {
+				return nil, errorAt(tok.Column, "unmatched )")
+			}
+
+			emptyCall := n == 0 && tokens[i-1].Kind == LeftParen
+			if expectOperand && !emptyCall {
+				return nil, errorAt(tok.Column, "expected an operand before )")
+			}
+
+			rpn = popUntilParen(ops, rpn)
+			ops.Pop()
+
+			if top, _ := ops.TryPeek(); n >= 0 && top.Kind == Call {
+				ops.Pop()
+
+				top.Args = n
+				if !emptyCall {
+					top.Args++
+				}
+
+				rpn = append(rpn, top)
+			}
+
+			expectOperand = false
+		}
+	}
+
+	if expectOperand {
+		column := 1
+		if len(tokens) > 0 {
+			last := tokens[len(tokens)-1]
+			column = last.Column + len([]rune(last.Text))
+		}
+
+		return nil, errorAt(column, "unexpected end of expression")
+	}
+
+	for !ops.IsEmpty() {
+		top, _ := ops.Pop()
+		if top.Kind == LeftParen {
+			return nil, errorAt(top.Column, "unmatched (")
+		}
+
+		rpn = append(rpn, top)
+	}
+
+	return rpn, nil
+}
+
+// popUntilParen moves operators to rpn until the innermost open parenthesis,
+// which it leaves on ops.
+func popUntilParen(ops *stack.Stack[Token], rpn []Token) []Token {
+	for {
+		top, _ := ops.TryPeek()
+		if top.Kind == LeftParen {
+			return rpn
+		}
+
+		ops.Pop()
+		rpn = append(rpn, top)
+	}
+}
@@ -0,0 +1,143 @@
+// Package expr parses and evaluates infix arithmetic.
+//
+// Source text is split into tokens by Tokenize, reordered into reverse Polish
+// notation by ToRPN using Dijkstra's shunting-yard algorithm, and evaluated by
+// EvalRPN against an Env of variables and functions. Parse builds an AST from
+// the same RPN, which renders back to infix with only the parentheses it
+// needs.
+//
+// From loosest to tightest, the operators are + and -, then *, / and %, then
+// unary minus, then ^. All binary operators are left-associative except ^, so
+// 2^3^2 is 2^(3^2) and -2^2 is -(2^2).
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+type Kind int
+
+const (
+	Number Kind = iota
+	Ident
+	Operator
+	LeftParen
+	RightParen
+	Comma
+	// Call and Negate are only produced by ToRPN, which tells function names
+	// from variables and unary minus from subtraction.
+	Call
+	Negate
+)
+
+type Token struct {
+	Kind  Kind
+	Text  string
+	Value float64
+	// Args is the number of arguments of a Call.
+	Args int
+	// Column is the 1-based position of the token's first character.
+	Column int
+}
+
+// Error is a syntax or evaluation error at a column of the source.
+type Error struct {
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func errorAt(column int, format string, args ...any) error {
+	return &Error{column, fmt.Sprintf(format, args...)}
+}
+
+func Tokenize(src string) ([]Token, error) {
+	runes := []rune(src)
+	var tokens []Token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case isDigit(r) || r == '.':
+			i = scanNumber(runes, i)
+			text := string(runes[start:i])
+
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, errorAt(start+1, "invalid number %q", text)
+			}
+
+			tokens = append(tokens, Token{Kind: Number, Text: text, Value: value, Column: start + 1})
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+
+			tokens = append(tokens, Token{Kind: Ident, Text: string(runes[start:i]), Column: start + 1})
+			continue
+		}
+
+		var kind Kind
+
+		switch r {
+		case '+', '-', '*', '/', '%', '^':
+			kind = Operator
+		case '(':
+			kind = LeftParen
+		case ')':
+			kind = RightParen
+		case ',':
+			kind = Comma
+		default:
+			return nil, errorAt(start+1, "unexpected character %q", r)
+		}
+
+		tokens = append(tokens, Token{Kind: kind, Text: string(r), Column: start + 1})
+		i++
+	}
+
+	return tokens, nil
+}
+
+// scanNumber returns the end of the number starting at i: digits with an
+// optional fraction and an optional exponent.
+func scanNumber(runes []rune, i int) int {
+	digits := func() {
+		for i < len(runes) && isDigit(runes[i]) {
+			i++
+		}
+	}
+
+	digits()
+	if i < len(runes) && runes[i] == '.' {
+		i++
+		digits()
+	}
+
+	if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+		j := i + 1
+		if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+			j++
+		}
+
+		if j < len(runes) && isDigit(runes[j]) {
+			i = j
+			digits()
+		}
+	}
+
+	return i
+}
+
+func isDigit(r rune) bool { return '0' <= r && r <= '9' }
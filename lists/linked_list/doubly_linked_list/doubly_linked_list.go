@@ -3,6 +3,8 @@ package doublylinkedlist
 import (
 	"fmt"
 	"sync"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/metrics"
 )
 
 type node struct {
@@ -12,14 +14,31 @@ type node struct {
 }
 
 type doublyLinkedList struct {
-	head *node
-	tail *node
-	len  int
-	mu   sync.Mutex
+	head    *node
+	tail    *node
+	len     int
+	tracker *metrics.Tracker[*node]
+	mu      sync.Mutex
+}
+
+type options struct {
+	observer metrics.Observer
+}
+
+type Option func(*options)
+
+// WithObserver reports every insertion and removal to o.
+func WithObserver(o metrics.Observer) Option {
+	return func(opts *options) { opts.observer = o }
 }
 
-func New() *doublyLinkedList {
-	return new(doublyLinkedList)
+func New(opts ...Option) *doublyLinkedList {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &doublyLinkedList{tracker: metrics.NewTracker[*node](o.observer)}
 }
 
 func (l *doublyLinkedList) Length() int {
@@ -38,6 +57,7 @@ func (l *doublyLinkedList) Prepend(item interface{}) {
 	}
 
 	l.len++
+	l.inserted(l.head)
 }
 
 func (l *doublyLinkedList) Append(item interface{}) {
@@ -52,6 +72,7 @@ func (l *doublyLinkedList) Append(item interface{}) {
 	}
 
 	l.len++
+	l.inserted(l.tail)
 }
 
 func (l *doublyLinkedList) RemoveHead() interface{} {
@@ -74,11 +95,20 @@ func (l *doublyLinkedList) RemoveTail() interface{} {
 		return nil
 	}
 
-	removed := l.tail.item
-	l.tail = l.tail.prev
-	l.tail.next = nil
+	removedNode := l.tail
+	l.tail = removedNode.prev
+	removedNode.prev = nil
+
+	if l.tail == nil {
+		l.head = nil
+	} else {
+		l.tail.next = nil
+	}
+
 	l.len--
-	return removed
+	l.removed(removedNode)
+
+	return removedNode.item
 }
 
 func (l *doublyLinkedList) RemoveAt(index int) (interface{}, error) {
@@ -178,8 +208,9 @@ func (l *doublyLinkedList) Print() {
 }
 
 func (l *doublyLinkedList) removeHeadAndDecrementLength() interface{} {
-	removed := l.head.item
+	removedNode := l.head
 	l.head = l.head.next
+	removedNode.next = nil
 
 	if l.head == nil {
 		l.tail = nil
@@ -188,8 +219,9 @@ func (l *doublyLinkedList) removeHeadAndDecrementLength() interface{} {
 	}
 
 	l.len--
+	l.removed(removedNode)
 
-	return removed
+	return removedNode.item
 }
 
 func (l *doublyLinkedList) setTailIfNewTailElseRemoveAndDecrement(beforeNodeToBeRemoved, nodeToBeRemoved *node) {
@@ -198,12 +230,24 @@ func (l *doublyLinkedList) setTailIfNewTailElseRemoveAndDecrement(beforeNodeToBe
 		beforeNodeToBeRemoved.next = nil
 	} else {
 		beforeNodeToBeRemoved.next = nodeToBeRemoved.next
+		nodeToBeRemoved.next.prev = beforeNodeToBeRemoved
 	}
 
+	nodeToBeRemoved.next = nil
+	nodeToBeRemoved.prev = nil
 	l.len--
+	l.removed(nodeToBeRemoved)
 }
 
 func (l *doublyLinkedList) addFirstItem(item interface{}) {
 	l.head = &node{item: item}
 	l.tail = l.head
 }
+
+func (l *doublyLinkedList) inserted(added *node) {
+	l.tracker.Inserted(added, l.len)
+}
+
+func (l *doublyLinkedList) removed(removed *node) {
+	l.tracker.Removed(removed, l.len)
+}
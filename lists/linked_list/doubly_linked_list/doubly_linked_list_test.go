@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
@@ -167,6 +168,26 @@ func TestRemoveTail(t *testing.T) {
 		utils.ValidateResult(t, got, want)
 	})
 
+	t.Run("Empties the list when removing the only item", func(t *testing.T) {
+		linkedList := New()
+		linkedList.Append("foo")
+
+		got := linkedList.RemoveTail()
+		want := "foo"
+		utils.ValidateResult(t, got, want)
+		utils.ValidateResult(t, linkedList.head, (*node)(nil))
+		utils.ValidateResult(t, linkedList.tail, (*node)(nil))
+	})
+
+	t.Run("Sets the 'prev' pointer of the removed tail to nil", testCase(func(t *testing.T, tc *testContext) {
+		oldTail := tc.linkedList.tail
+		tc.linkedList.RemoveTail()
+
+		got := oldTail.prev
+		var want *node = nil
+		utils.ValidateResult(t, got, want)
+	}))
+
 	t.Run("Sets the 'next' pointer of the item before tail to nil", testCase(func(t *testing.T, tc *testContext) {
 		nextAfterTail := tc.linkedList.head
 
@@ -240,6 +261,34 @@ func TestRemoveAt(t *testing.T) {
 		utils.ValidateResult(t, got, want)
 	}))
 
+	t.Run("Links the next item back to the one before the removed item", testCase(func(t *testing.T, tc *testContext) {
+		tc.linkedList.RemoveAt(1)
+		got := tc.linkedList.head.next.prev
+		want := tc.linkedList.head
+		utils.ValidateResult(t, got, want)
+	}))
+
+	t.Run("Unlinks the removed node", testCase(func(t *testing.T, tc *testContext) {
+		removedNode := tc.linkedList.head.next
+		tc.linkedList.RemoveAt(1)
+		utils.ValidateResult(t, removedNode.next, (*node)(nil))
+		utils.ValidateResult(t, removedNode.prev, (*node)(nil))
+	}))
+
+	t.Run("Leaves the list walkable from the tail", func(t *testing.T) {
+		linkedList := New()
+		linkedList.Append("a")
+		linkedList.Append("b")
+		linkedList.Append("c")
+
+		linkedList.RemoveAt(1)
+
+		utils.ValidateResult(t, linkedList.RemoveTail(), "c")
+		utils.ValidateResult(t, linkedList.RemoveTail(), "a")
+		utils.ValidateResult(t, linkedList.RemoveTail(), nil)
+		utils.ValidateResult(t, linkedList.Length(), 0)
+	})
+
 	t.Run("Throws error when negative bounds", testCase(func(t *testing.T, tc *testContext) {
 		_, err := tc.linkedList.RemoveAt(-1)
 		if err == nil {
@@ -282,6 +331,19 @@ func TestRemoveItem(t *testing.T) {
 		want := items[1]
 		utils.ValidateResult(t, got, want)
 	}))
+	t.Run("Leaves the list walkable from the tail", func(t *testing.T) {
+		linkedList := New()
+		linkedList.Append("a")
+		linkedList.Append("b")
+		linkedList.Append("c")
+
+		linkedList.RemoveItem("b")
+
+		utils.ValidateResult(t, linkedList.RemoveTail(), "c")
+		utils.ValidateResult(t, linkedList.RemoveTail(), "a")
+		utils.ValidateResult(t, linkedList.RemoveTail(), nil)
+		utils.ValidateResult(t, linkedList.Length(), 0)
+	})
 }
 
 func TestFind(t *testing.T) {
@@ -344,3 +406,64 @@ func TestIterate(t *testing.T) {
 		}
 	}))
 }
+
+type recordingObserver struct {
+	sizes  []int
+	waited []time.Duration
+}
+
+func (o *recordingObserver) OnInsert(size int) {
+	o.sizes = append(o.sizes, size)
+}
+
+func (o *recordingObserver) OnRemove(size int, waited time.Duration) {
+	o.sizes = append(o.sizes, -size)
+	o.waited = append(o.waited, waited)
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("Reports insertions and removals with the resulting length", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		l.Append(2)
+		l.Prepend(0)
+		l.Append(3)
+		l.RemoveAt(1)
+		l.RemoveItem(2)
+		l.RemoveTail()
+		l.RemoveHead()
+
+		want := []int{1, 2, 3, 4, -3, -2, -1, 0}
+		if diff := cmp.Diff(want, o.sizes); diff != "" {
+			t.Errorf("Observed sizes mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Reports how long the removed item was held", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		time.Sleep(time.Millisecond)
+		l.Append(2)
+		l.RemoveHead()
+		l.RemoveHead()
+
+		if o.waited[0] < time.Millisecond || o.waited[0] < o.waited[1] {
+			t.Errorf("Expected the first item to have waited longer than 1ms and than the second but got %v", o.waited)
+		}
+	})
+
+	t.Run("Does not report failed removals", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		l.RemoveAt(5)
+		l.RemoveItem(2)
+
+		utils.ValidateResult(t, len(o.sizes), 1)
+	})
+}
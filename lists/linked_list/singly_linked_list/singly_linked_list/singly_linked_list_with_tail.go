@@ -3,6 +3,8 @@ package linkedlist
 import (
 	"fmt"
 	"sync"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/metrics"
 )
 
 type node struct {
@@ -11,13 +13,30 @@ type node struct {
 }
 
 type linkedList struct {
-	head *node
-	len  int
-	mu   sync.Mutex
+	head    *node
+	len     int
+	tracker *metrics.Tracker[*node]
+	mu      sync.Mutex
 }
 
-func New() *linkedList {
-	return new(linkedList)
+type options struct {
+	observer metrics.Observer
+}
+
+type Option func(*options)
+
+// WithObserver reports every insertion and removal to o.
+func WithObserver(o metrics.Observer) Option {
+	return func(opts *options) { opts.observer = o }
+}
+
+func New(opts ...Option) *linkedList {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &linkedList{tracker: metrics.NewTracker[*node](o.observer)}
 }
 
 func (l *linkedList) Length() int {
@@ -35,24 +54,28 @@ func (l *linkedList) Prepend(item interface{}) {
 	}
 
 	l.len++
+	l.inserted(l.head)
 }
 
 func (l *linkedList) Append(item interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	added := &node{item: item}
+
 	if l.head == nil {
-		l.addFirstItem(item)
+		l.head = added
 	} else {
 		current := l.head
 		for current.next != nil {
 			current = current.next
 		}
 
-		current.next = &node{item: item}
+		current.next = added
 	}
 
 	l.len++
+	l.inserted(added)
 }
 
 func (l *linkedList) RemoveHead() interface{} {
@@ -81,10 +104,12 @@ func (l *linkedList) RemoveTail() interface{} {
 		beforeTail = beforeTail.next
 	}
 
-	removed := beforeTail.next.item
+	removedNode := beforeTail.next
 	beforeTail.next = nil
 	l.len--
-	return removed
+	l.removed(removedNode)
+
+	return removedNode.item
 }
 
 func (l *linkedList) RemoveAt(index int) (interface{}, error) {
@@ -184,19 +209,29 @@ func (l *linkedList) Print() {
 }
 
 func (l *linkedList) removeHeadAndDecrementLength() interface{} {
-	removed := l.head.item
+	removedNode := l.head
 	l.head = l.head.next
 	l.len--
+	l.removed(removedNode)
 
-	return removed
+	return removedNode.item
 }
 
 func (l *linkedList) removeAndDecrementLength(beforeNodeToBeRemoved, nodeToBeRemoved *node) {
 	beforeNodeToBeRemoved.next = nodeToBeRemoved.next
 
 	l.len--
+	l.removed(nodeToBeRemoved)
 }
 
 func (l *linkedList) addFirstItem(item interface{}) {
 	l.head = &node{item: item}
 }
+
+func (l *linkedList) inserted(added *node) {
+	l.tracker.Inserted(added, l.len)
+}
+
+func (l *linkedList) removed(removed *node) {
+	l.tracker.Removed(removed, l.len)
+}
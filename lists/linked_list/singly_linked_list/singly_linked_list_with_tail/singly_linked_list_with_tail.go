@@ -3,6 +3,8 @@ package linkedlistwithtail
 import (
 	"fmt"
 	"sync"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/metrics"
 )
 
 type node struct {
@@ -11,14 +13,31 @@ type node struct {
 }
 
 type linkedList struct {
-	head *node
-	tail *node
-	len  int
-	mu   sync.Mutex
+	head    *node
+	tail    *node
+	len     int
+	tracker *metrics.Tracker[*node]
+	mu      sync.Mutex
+}
+
+type options struct {
+	observer metrics.Observer
+}
+
+type Option func(*options)
+
+// WithObserver reports every insertion and removal to o.
+func WithObserver(o metrics.Observer) Option {
+	return func(opts *options) { opts.observer = o }
 }
 
-func New() *linkedList {
-	return new(linkedList)
+func New(opts ...Option) *linkedList {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &linkedList{tracker: metrics.NewTracker[*node](o.observer)}
 }
 
 func (l *linkedList) Length() int {
@@ -36,6 +55,7 @@ func (l *linkedList) Prepend(item interface{}) {
 	}
 
 	l.len++
+	l.inserted(l.head)
 }
 
 func (l *linkedList) Append(item interface{}) {
@@ -50,6 +70,7 @@ func (l *linkedList) Append(item interface{}) {
 	}
 
 	l.len++
+	l.inserted(l.tail)
 }
 
 func (l *linkedList) RemoveHead() interface{} {
@@ -78,11 +99,13 @@ func (l *linkedList) RemoveTail() interface{} {
 		beforeTail = beforeTail.next
 	}
 
+	removedNode := l.tail
 	beforeTail.next = nil
-	removed := l.tail.item
 	l.tail = beforeTail
 	l.len--
-	return removed
+	l.removed(removedNode)
+
+	return removedNode.item
 }
 
 func (l *linkedList) RemoveAt(index int) (interface{}, error) {
@@ -182,14 +205,15 @@ func (l *linkedList) Print() {
 }
 
 func (l *linkedList) removeHeadAndDecrementLength() interface{} {
-	removed := l.head.item
+	removedNode := l.head
 	l.head = l.head.next
 	if l.head == nil {
 		l.tail = nil
 	}
 	l.len--
+	l.removed(removedNode)
 
-	return removed
+	return removedNode.item
 }
 
 func (l *linkedList) setTailIfNewTailElseRemoveAndDecrement(beforeNodeToBeRemoved, nodeToBeRemoved *node) {
@@ -201,9 +225,18 @@ func (l *linkedList) setTailIfNewTailElseRemoveAndDecrement(beforeNodeToBeRemove
 	}
 
 	l.len--
+	l.removed(nodeToBeRemoved)
 }
 
 func (l *linkedList) addFirstItem(item interface{}) {
 	l.head = &node{item: item}
 	l.tail = l.head
 }
+
+func (l *linkedList) inserted(added *node) {
+	l.tracker.Inserted(added, l.len)
+}
+
+func (l *linkedList) removed(removed *node) {
+	l.tracker.Removed(removed, l.len)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	// "slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
@@ -345,3 +346,64 @@ func TestIterate(t *testing.T) {
 		}
 	}))
 }
+
+type recordingObserver struct {
+	sizes  []int
+	waited []time.Duration
+}
+
+func (o *recordingObserver) OnInsert(size int) {
+	o.sizes = append(o.sizes, size)
+}
+
+func (o *recordingObserver) OnRemove(size int, waited time.Duration) {
+	o.sizes = append(o.sizes, -size)
+	o.waited = append(o.waited, waited)
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("Reports insertions and removals with the resulting length", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		l.Append(2)
+		l.Prepend(0)
+		l.Append(3)
+		l.RemoveAt(1)
+		l.RemoveItem(2)
+		l.RemoveTail()
+		l.RemoveHead()
+
+		want := []int{1, 2, 3, 4, -3, -2, -1, 0}
+		if diff := cmp.Diff(want, o.sizes); diff != "" {
+			t.Errorf("Observed sizes mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Reports how long the removed item was held", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		time.Sleep(time.Millisecond)
+		l.Append(2)
+		l.RemoveHead()
+		l.RemoveHead()
+
+		if o.waited[0] < time.Millisecond || o.waited[0] < o.waited[1] {
+			t.Errorf("Expected the first item to have waited longer than 1ms and than the second but got %v", o.waited)
+		}
+	})
+
+	t.Run("Does not report failed removals", func(t *testing.T) {
+		o := &recordingObserver{}
+		l := New(WithObserver(o))
+
+		l.Append(1)
+		l.RemoveAt(5)
+		l.RemoveItem(2)
+
+		utils.ValidateResult(t, len(o.sizes), 1)
+	})
+}
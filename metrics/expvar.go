@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"expvar"
+	"time"
+)
+
+// waitBuckets are the upper bounds of the wait-time histogram. Waits longer
+// than the last bound are counted under "inf".
+var waitBuckets = []time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// Expvar publishes what it observes as an expvar map with these keys:
+//
+//	size       gauge of the items currently held
+//	inserts    counter of items added
+//	removes    counter of items removed
+//	wait_ns    total nanoseconds removed items were held
+//	wait_hist  histogram of wait times, keyed by upper bound
+type Expvar struct {
+	size      expvar.Int
+	inserts   expvar.Int
+	removes   expvar.Int
+	waitNanos expvar.Int
+	buckets   []expvar.Int
+	overflow  expvar.Int
+}
+
+// NewExpvar publishes a new observer under name. Like expvar.Publish, it
+// panics if the name is already in use.
+func NewExpvar(name string) *Expvar {
+	e, m := newExpvar()
+	expvar.Publish(name, m)
+	return e
+}
+
+// newExpvar builds an observer and its map without publishing the map, which
+// would tie it to a process-wide name.
+func newExpvar() (*Expvar, *expvar.Map) {
+	e := &Expvar{buckets: make([]expvar.Int, len(waitBuckets))}
+
+	hist := new(expvar.Map)
+	for i, bound := range waitBuckets {
+		hist.Set(bound.String(), &e.buckets[i])
+	}
+	hist.Set("inf", &e.overflow)
+
+	m := new(expvar.Map)
+	m.Set("size", &e.size)
+	m.Set("inserts", &e.inserts)
+	m.Set("removes", &e.removes)
+	m.Set("wait_ns", &e.waitNanos)
+	m.Set("wait_hist", hist)
+
+	return e, m
+}
+
+func (e *Expvar) OnInsert(size int) {
+	e.inserts.Add(1)
+	e.size.Set(int64(size))
+}
+
+func (e *Expvar) OnRemove(size int, waited time.Duration) {
+	e.removes.Add(1)
+	e.size.Set(int64(size))
+	e.waitNanos.Add(int64(waited))
+
+	for i, bound := range waitBuckets {
+		if waited <= bound {
+			e.buckets[i].Add(1)
+			return
+		}
+	}
+
+	e.overflow.Add(1)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestExpvar(t *testing.T) {
+	e, m := newExpvar()
+
+	e.OnInsert(1)
+	e.OnInsert(2)
+	e.OnRemove(1, 5*time.Millisecond)
+	e.OnRemove(0, time.Minute)
+
+	hist := m.Get("wait_hist").(*expvar.Map)
+
+	utils.ValidateResult(t, m.Get("size").(*expvar.Int).Value(), int64(0))
+	utils.ValidateResult(t, m.Get("inserts").(*expvar.Int).Value(), int64(2))
+	utils.ValidateResult(t, m.Get("removes").(*expvar.Int).Value(), int64(2))
+	utils.ValidateResult(t, m.Get("wait_ns").(*expvar.Int).Value(), int64(5*time.Millisecond+time.Minute))
+	utils.ValidateResult(t, hist.Get("10ms").(*expvar.Int).Value(), int64(1))
+	utils.ValidateResult(t, hist.Get("1ms").(*expvar.Int).Value(), int64(0))
+	utils.ValidateResult(t, hist.Get("inf").(*expvar.Int).Value(), int64(1))
+}
+
+func TestSince(t *testing.T) {
+	start := Now()
+	time.Sleep(time.Millisecond)
+
+	if waited := Since(start); waited < time.Millisecond {
+		t.Errorf("Expected at least 1ms to have passed but got %v", waited)
+	}
+}
+
+// published counts the runs of TestNewExpvar, since each one has to publish
+// under a name that no earlier run in the process used.
+var published int
+
+func TestNewExpvar(t *testing.T) {
+	published++
+	name := fmt.Sprintf("metrics_test_published_%d", published)
+
+	e := NewExpvar(name)
+	e.OnInsert(1)
+
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		t.Fatal("Expected NewExpvar to publish its map")
+	}
+	utils.ValidateResult(t, m.Get("size").(*expvar.Int).Value(), int64(1))
+}
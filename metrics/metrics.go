@@ -0,0 +1,29 @@
+// Package metrics defines the hooks through which the data structures in this
+// module report how full they are and how long items stay in them.
+package metrics
+
+import "time"
+
+// Observer is told about every item that enters or leaves a data structure.
+// Queues report Enqueue and Dequeue, stacks Push and Pop, and lists their
+// insertions and removals. size is the number of items held after the change
+// and waited is how long the removed item was held.
+//
+// The callbacks run while the data structure holds its lock, so they must be
+// quick and must not call back into it.
+type Observer interface {
+	OnInsert(size int)
+	OnRemove(size int, waited time.Duration)
+}
+
+var epoch = time.Now()
+
+// Now returns a monotonic timestamp that is cheaper to store than a time.Time.
+func Now() int64 {
+	return int64(time.Since(epoch))
+}
+
+// Since returns the time elapsed since a timestamp returned by Now.
+func Since(timestamp int64) time.Duration {
+	return time.Duration(Now() - timestamp)
+}
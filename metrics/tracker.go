@@ -0,0 +1,43 @@
+package metrics
+
+// Tracker reports to an Observer on behalf of a data structure and remembers
+// when each item was inserted, keyed by whatever identifies the item there,
+// such as its node. Keeping the timestamps here rather than in the nodes means
+// they cost nothing when no observer is configured.
+//
+// A nil *Tracker ignores every call, so data structures can hold one without
+// checking whether an observer was given.
+type Tracker[K comparable] struct {
+	observer Observer
+	added    map[K]int64
+}
+
+// NewTracker returns a Tracker reporting to o, or nil if o is nil.
+func NewTracker[K comparable](o Observer) *Tracker[K] {
+	if o == nil {
+		return nil
+	}
+
+	return &Tracker[K]{observer: o, added: make(map[K]int64)}
+}
+
+// Inserted records that key was inserted, leaving size items held.
+func (t *Tracker[K]) Inserted(key K, size int) {
+	if t == nil {
+		return
+	}
+
+	t.added[key] = Now()
+	t.observer.OnInsert(size)
+}
+
+// Removed records that key was removed, leaving size items held.
+func (t *Tracker[K]) Removed(key K, size int) {
+	if t == nil {
+		return
+	}
+
+	added := t.added[key]
+	delete(t.added, key)
+	t.observer.OnRemove(size, Since(added))
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+type recordingObserver struct {
+	sizes  []int
+	waited []time.Duration
+}
+
+func (o *recordingObserver) OnInsert(size int) { o.sizes = append(o.sizes, size) }
+
+func (o *recordingObserver) OnRemove(size int, waited time.Duration) {
+	o.sizes = append(o.sizes, size)
+	o.waited = append(o.waited, waited)
+}
+
+func TestTracker(t *testing.T) {
+	t.Run("Reports how long each key was held", func(t *testing.T) {
+		o := &recordingObserver{}
+		tracker := NewTracker[string](o)
+
+		tracker.Inserted("a", 1)
+		time.Sleep(time.Millisecond)
+		tracker.Inserted("b", 2)
+		tracker.Removed("a", 1)
+		tracker.Removed("b", 0)
+
+		utils.ValidateResult(t, len(o.sizes), 4)
+		for i, want := range []int{1, 2, 1, 0} {
+			utils.ValidateResult(t, o.sizes[i], want)
+		}
+
+		if o.waited[0] < time.Millisecond || o.waited[0] < o.waited[1] {
+			t.Errorf("Expected a to have waited longer than 1ms and than b but got %v", o.waited)
+		}
+		utils.ValidateResult(t, len(tracker.added), 0)
+	})
+
+	t.Run("Is nil without an observer", func(t *testing.T) {
+		tracker := NewTracker[string](nil)
+		utils.ValidateResult(t, tracker == nil, true)
+
+		tracker.Inserted("a", 1)
+		tracker.Removed("a", 0)
+	})
+}
@@ -0,0 +1,226 @@
+// Package priorityqueue implements a d-ary heap ordered by a caller-supplied
+// less function. The item for which less reports true against every other
+// item is served first, so a less of a < b yields a min-queue.
+package priorityqueue
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrInvalidHandle = errors.New("handle does not refer to an item in this priority queue")
+
+// Handle refers to an item pushed onto a priority queue. It stays valid until
+// the item is popped or removed.
+type Handle[T any] struct {
+	item  T
+	index int
+	mu    *sync.Mutex
+}
+
+// Value returns the item the handle refers to. It takes the queue's lock, so
+// it is safe to call while other goroutines Update the item.
+func (h *Handle[T]) Value() T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.item
+}
+
+type config struct {
+	arity int
+}
+
+type Option func(*config)
+
+// WithArity sets how many children each heap node has. Higher arities make
+// the heap shallower, which speeds up Push and Update at the cost of Pop.
+// The default is 2.
+func WithArity(d int) Option {
+	return func(c *config) { c.arity = d }
+}
+
+type PriorityQueue[T any] struct {
+	heap  []*Handle[T]
+	less  func(a, b T) bool
+	arity int
+	mu    sync.Mutex
+}
+
+func New[T any](less func(a, b T) bool, opts ...Option) *PriorityQueue[T] {
+	c := config{arity: 2}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &PriorityQueue[T]{less: less, arity: max(c.arity, 2)}
+}
+
+// Heapify replaces the contents of the queue with items in O(n) and returns
+// their handles in the order the items were given.
+func (pq *PriorityQueue[T]) Heapify(items []T) []*Handle[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	for _, h := range pq.heap {
+		h.index = -1
+	}
+
+	handles := make([]*Handle[T], len(items))
+	pq.heap = make([]*Handle[T], len(items))
+
+	for i, item := range items {
+		handles[i] = &Handle[T]{item: item, index: i, mu: &pq.mu}
+		pq.heap[i] = handles[i]
+	}
+
+	for i := (len(pq.heap) - 2) / pq.arity; i >= 0; i-- {
+		pq.down(i)
+	}
+
+	return handles
+}
+
+func (pq *PriorityQueue[T]) Push(item T) *Handle[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	h := &Handle[T]{item: item, index: len(pq.heap), mu: &pq.mu}
+	pq.heap = append(pq.heap, h)
+	pq.up(h.index)
+
+	return h
+}
+
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return pq.removeAt(0), true
+}
+
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return pq.heap[0].item, true
+}
+
+// Update replaces the item behind h and restores the heap order in
+// O(log n).
+func (pq *PriorityQueue[T]) Update(h *Handle[T], item T) error {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if !pq.owns(h) {
+		return ErrInvalidHandle
+	}
+
+	h.item = item
+	pq.fix(h.index)
+
+	return nil
+}
+
+func (pq *PriorityQueue[T]) Remove(h *Handle[T]) (T, error) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if !pq.owns(h) {
+		var zero T
+		return zero, ErrInvalidHandle
+	}
+
+	return pq.removeAt(h.index), nil
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	return len(pq.heap)
+}
+
+func (pq *PriorityQueue[T]) IsEmpty() bool { return pq.Len() == 0 }
+
+func (pq *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.heap) && pq.heap[h.index] == h
+}
+
+func (pq *PriorityQueue[T]) removeAt(i int) T {
+	removed := pq.heap[i]
+	last := len(pq.heap) - 1
+
+	pq.swap(i, last)
+	pq.heap[last] = nil
+	pq.heap = pq.heap[:last]
+	removed.index = -1
+
+	if i < last {
+		pq.fix(i)
+	}
+
+	return removed.item
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / pq.arity
+		if !pq.less(pq.heap[i].item, pq.heap[parent].item) {
+			return
+		}
+
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the item at i towards the leaves and reports whether it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+
+	for {
+		first := pq.arity*i + 1
+		if first >= len(pq.heap) {
+			break
+		}
+
+		smallest := first
+		for c := first + 1; c < first+pq.arity && c < len(pq.heap); c++ {
+			if pq.less(pq.heap[c].item, pq.heap[smallest].item) {
+				smallest = c
+			}
+		}
+
+		if !pq.less(pq.heap[smallest].item, pq.heap[i].item) {
+			break
+		}
+
+		pq.swap(i, smallest)
+		i = smallest
+	}
+
+	return i > start
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.heap[i].index = i
+	pq.heap[j].index = j
+}
@@ -0,0 +1,270 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []int{5, 3, 8, 1, 9, 2}
+
+func less(a, b int) bool { return a < b }
+
+type testContext struct {
+	pq      *PriorityQueue[int]
+	handles []*Handle[int]
+}
+
+func (c *testContext) beforeEach() {
+	pq := New(less)
+
+	for _, item := range items {
+		c.handles = append(c.handles, pq.Push(item))
+	}
+
+	c.pq = pq
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func popAll(pq *PriorityQueue[int]) []int {
+	var popped []int
+
+	for {
+		item, ok := pq.Pop()
+		if !ok {
+			return popped
+		}
+
+		popped = append(popped, item)
+	}
+}
+
+func sorted(items []int) []int {
+	s := slices.Clone(items)
+	slices.Sort(s)
+	return s
+}
+
+func TestPush(t *testing.T) {
+	t.Run("Keeps the least item at the root", testCase(func(t *testing.T, tc *testContext) {
+		got, _ := tc.pq.Peek()
+		want := slices.Min(items)
+		utils.ValidateResult(t, got, want)
+	}))
+
+	t.Run("Increments length", testCase(func(t *testing.T, tc *testContext) {
+		utils.ValidateResult(t, tc.pq.Len(), len(items))
+	}))
+
+	t.Run("Returns handles to the pushed items", testCase(func(t *testing.T, tc *testContext) {
+		for i, h := range tc.handles {
+			utils.ValidateResult(t, h.Value(), items[i])
+		}
+	}))
+}
+
+func TestPop(t *testing.T) {
+	t.Run("Returns items in priority order", testCase(func(t *testing.T, tc *testContext) {
+		got := popAll(tc.pq)
+		want := sorted(items)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}))
+
+	t.Run("Reports an empty queue", func(t *testing.T) {
+		_, ok := New(less).Pop()
+		utils.ValidateResult(t, ok, false)
+	})
+
+	t.Run("Invalidates the popped handle", testCase(func(t *testing.T, tc *testContext) {
+		tc.pq.Pop()
+
+		err := tc.pq.Update(tc.handles[3], 0)
+		utils.ValidateResult(t, err, ErrInvalidHandle)
+	}))
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("Moves an item towards the root when it gains priority", testCase(func(t *testing.T, tc *testContext) {
+		if err := tc.pq.Update(tc.handles[4], 0); err != nil {
+			t.Fatalf("Could not update item: %s", err.Error())
+		}
+
+		got, _ := tc.pq.Peek()
+		utils.ValidateResult(t, got, 0)
+	}))
+
+	t.Run("Moves an item towards the leaves when it loses priority", testCase(func(t *testing.T, tc *testContext) {
+		tc.pq.Update(tc.handles[3], 10)
+
+		got := popAll(tc.pq)
+		want := []int{2, 3, 5, 8, 9, 10}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}))
+
+	t.Run("Can be read through the handle while it changes", func(t *testing.T) {
+		pq := New(less)
+		h := pq.Push(0)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= 1000; i++ {
+				pq.Update(h, i)
+			}
+		}()
+
+		for i := 0; i < 1000; i++ {
+			h.Value()
+		}
+		wg.Wait()
+
+		utils.ValidateResult(t, h.Value(), 1000)
+	})
+
+	t.Run("Rejects a handle from another queue", testCase(func(t *testing.T, tc *testContext) {
+		other := New(less)
+		other.Push(1)
+
+		err := other.Update(tc.handles[0], 0)
+		utils.ValidateResult(t, err, ErrInvalidHandle)
+	}))
+}
+
+func TestRemove(t *testing.T) {
+	t.Run("Removes an arbitrary item", testCase(func(t *testing.T, tc *testContext) {
+		got, err := tc.pq.Remove(tc.handles[2])
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, got, 8)
+
+		remaining := popAll(tc.pq)
+		want := []int{1, 2, 3, 5, 9}
+
+		if !slices.Equal(remaining, want) {
+			t.Errorf("got: %v, want: %v", remaining, want)
+		}
+	}))
+
+	t.Run("Rejects a handle that was already removed", testCase(func(t *testing.T, tc *testContext) {
+		tc.pq.Remove(tc.handles[0])
+
+		_, err := tc.pq.Remove(tc.handles[0])
+		utils.ValidateResult(t, err, ErrInvalidHandle)
+	}))
+}
+
+func TestHeapify(t *testing.T) {
+	t.Run("Orders the given items", func(t *testing.T) {
+		pq := New(less)
+		handles := pq.Heapify(items)
+
+		for i, h := range handles {
+			utils.ValidateResult(t, h.Value(), items[i])
+		}
+
+		got := popAll(pq)
+		want := sorted(items)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	})
+
+	t.Run("Invalidates handles to the replaced items", testCase(func(t *testing.T, tc *testContext) {
+		tc.pq.Heapify([]int{4})
+
+		_, err := tc.pq.Remove(tc.handles[0])
+		utils.ValidateResult(t, err, ErrInvalidHandle)
+	}))
+}
+
+func TestMatchesSortedModel(t *testing.T) {
+	for _, arity := range []int{2, 3, 4, 8} {
+		rng := rand.New(rand.NewSource(int64(arity)))
+		pq := New(less, WithArity(arity))
+		model := map[*Handle[int]]int{}
+
+		initial := make([]int, 100)
+		for i := range initial {
+			initial[i] = rng.Intn(1000)
+		}
+		for i, h := range pq.Heapify(initial) {
+			model[h] = initial[i]
+		}
+
+		for i := 0; i < 5000; i++ {
+			switch rng.Intn(4) {
+			case 0:
+				item := rng.Intn(1000)
+				model[pq.Push(item)] = item
+			case 1:
+				for h := range model {
+					item := rng.Intn(1000)
+					pq.Update(h, item)
+					model[h] = item
+					break
+				}
+			case 2:
+				for h := range model {
+					got, _ := pq.Remove(h)
+					utils.ValidateResult(t, got, model[h])
+					delete(model, h)
+					break
+				}
+			default:
+				got, ok := pq.Pop()
+				utils.ValidateResult(t, ok, len(model) > 0)
+				if !ok {
+					continue
+				}
+
+				for h, item := range model {
+					if item < got {
+						t.Fatalf("arity %d: popped %d while %d was queued", arity, got, item)
+					}
+					if item == got && h.index == -1 {
+						delete(model, h)
+					}
+				}
+			}
+
+			utils.ValidateResult(t, pq.Len(), len(model))
+		}
+	}
+}
+
+func TestConcurrentPushAndPop(t *testing.T) {
+	pq := New(less, WithArity(4))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				pq.Push(g*1000 + i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := popAll(pq)
+	utils.ValidateResult(t, len(got), 8000)
+	utils.ValidateResult(t, slices.IsSorted(got), true)
+}
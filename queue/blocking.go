@@ -0,0 +1,199 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrClosed = errors.New("queue is closed")
+
+// Blocking is a FIFO queue whose Put and Take wait for room and items
+// respectively. A capacity of zero or less makes it unbounded, in which case
+// Put never waits.
+type Blocking[T any] struct {
+	items    Queue[T]
+	capacity int
+	closed   bool
+	changed  chan struct{}
+	mu       sync.Mutex
+}
+
+func NewBlocking[T any](capacity int) *Blocking[T] {
+	return &Blocking[T]{capacity: capacity, changed: make(chan struct{})}
+}
+
+// Put enqueues item, waiting while the queue is full. It returns ErrClosed if
+// the queue is or becomes closed, or the context's error if ctx is done first.
+func (b *Blocking[T]) Put(ctx context.Context, item T) error {
+	for {
+		b.mu.Lock()
+
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+
+		if !b.isFull() {
+			b.items.enqueue(item)
+			b.broadcast()
+			b.mu.Unlock()
+			return nil
+		}
+
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Take dequeues the first item, waiting while the queue is empty. Items left
+// in a closed queue can still be taken; ErrClosed is returned once it is
+// drained.
+func (b *Blocking[T]) Take(ctx context.Context) (T, error) {
+	for {
+		b.mu.Lock()
+
+		if item, ok := b.items.dequeue(); ok {
+			b.broadcast()
+			b.mu.Unlock()
+			return item, nil
+		}
+
+		if b.closed {
+			b.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// DequeueBatch waits for a first item like Take and then collects up to
+// maxItems items. It returns as soon as it has that many, or once maxWait has
+// passed since the first one arrived, or once ctx is done, whichever comes
+// first. Items collected before ctx is done are returned without an error.
+func (b *Blocking[T]) DequeueBatch(ctx context.Context, maxItems int, maxWait time.Duration) ([]T, error) {
+	first, err := b.Take(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maxItems = max(maxItems, 1)
+	batch := append(make([]T, 0, maxItems), first)
+
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+
+	for len(batch) < maxItems {
+		b.mu.Lock()
+
+		items := b.items.dequeueN(maxItems - len(batch))
+		if len(items) > 0 {
+			batch = append(batch, items...)
+			b.broadcast()
+		}
+
+		if len(batch) == maxItems || b.closed {
+			b.mu.Unlock()
+			break
+		}
+
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return batch, nil
+		case <-ctx.Done():
+			return batch, nil
+		}
+	}
+
+	return batch, nil
+}
+
+func (b *Blocking[T]) Offer(item T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed || b.isFull() {
+		return false
+	}
+
+	b.items.enqueue(item)
+	b.broadcast()
+	return true
+}
+
+func (b *Blocking[T]) Poll() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	item, ok := b.items.dequeue()
+	if ok {
+		b.broadcast()
+	}
+
+	return item, ok
+}
+
+func (b *Blocking[T]) PollTimeout(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	item, err := b.Take(ctx)
+	return item, err == nil
+}
+
+// Close rejects further puts and wakes every waiter. Calling it more than once
+// has no effect.
+func (b *Blocking[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	b.broadcast()
+}
+
+func (b *Blocking[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.items.len
+}
+
+func (b *Blocking[T]) Cap() int { return b.capacity }
+
+func (b *Blocking[T]) IsEmpty() bool { return b.Len() == 0 }
+
+func (b *Blocking[T]) isFull() bool {
+	return b.capacity > 0 && b.items.len >= b.capacity
+}
+
+// broadcast wakes everyone waiting on the current channel and hands out a
+// fresh one to later waiters.
+func (b *Blocking[T]) broadcast() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
@@ -0,0 +1,284 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestBlockingPut(t *testing.T) {
+	t.Run("Enqueues items in FIFO order", func(t *testing.T) {
+		b := NewBlocking[int](0)
+
+		for i := 0; i < 3; i++ {
+			if err := b.Put(context.Background(), i); err != nil {
+				t.Fatalf("Could not put item: %s", err.Error())
+			}
+		}
+
+		for i := 0; i < 3; i++ {
+			got, _ := b.Poll()
+			utils.ValidateResult(t, got, i)
+		}
+	})
+
+	t.Run("Blocks while full until an item is taken", func(t *testing.T) {
+		b := NewBlocking[int](1)
+		b.Put(context.Background(), 1)
+
+		done := make(chan error)
+		go func() { done <- b.Put(context.Background(), 2) }()
+
+		select {
+		case <-done:
+			t.Fatal("Expected Put to block on a full queue but it returned")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		b.Poll()
+
+		if err := <-done; err != nil {
+			t.Fatalf("Could not put item: %s", err.Error())
+		}
+
+		got, _ := b.Poll()
+		utils.ValidateResult(t, got, 2)
+	})
+
+	t.Run("Returns the context error when cancelled", func(t *testing.T) {
+		b := NewBlocking[int](1)
+		b.Put(context.Background(), 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		got := b.Put(ctx, 2)
+		want := context.DeadlineExceeded
+		utils.ValidateResult(t, got, want)
+		utils.ValidateResult(t, b.Len(), 1)
+	})
+}
+
+func TestBlockingTake(t *testing.T) {
+	t.Run("Blocks while empty until an item is put", func(t *testing.T) {
+		b := NewBlocking[string](0)
+
+		got := make(chan string)
+		go func() {
+			item, _ := b.Take(context.Background())
+			got <- item
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		b.Offer("foo")
+
+		utils.ValidateResult(t, <-got, "foo")
+	})
+
+	t.Run("Returns the context error when cancelled", func(t *testing.T) {
+		b := NewBlocking[int](0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, got := b.Take(ctx)
+		want := context.Canceled
+		utils.ValidateResult(t, got, want)
+	})
+
+	t.Run("Delivers every item exactly once to concurrent consumers", func(t *testing.T) {
+		const producers, perProducer = 4, 250
+		b := NewBlocking[int](8)
+		seen := make([]int, producers*perProducer)
+
+		var consumers sync.WaitGroup
+		var mu sync.Mutex
+		for i := 0; i < 4; i++ {
+			consumers.Add(1)
+			go func() {
+				defer consumers.Done()
+				for {
+					item, err := b.Take(context.Background())
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					seen[item]++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					b.Put(context.Background(), p*perProducer+i)
+				}
+			}()
+		}
+
+		wg.Wait()
+		b.Close()
+		consumers.Wait()
+
+		for item, count := range seen {
+			if count != 1 {
+				t.Errorf("Expected item %d to be taken once but it was taken %d times", item, count)
+			}
+		}
+	})
+}
+
+func TestBlockingOfferAndPoll(t *testing.T) {
+	t.Run("Offer fails when full", func(t *testing.T) {
+		b := NewBlocking[int](1)
+		utils.ValidateResult(t, b.Offer(1), true)
+		utils.ValidateResult(t, b.Offer(2), false)
+	})
+
+	t.Run("Poll fails when empty", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		_, ok := b.Poll()
+		utils.ValidateResult(t, ok, false)
+	})
+
+	t.Run("PollTimeout gives up after the timeout", func(t *testing.T) {
+		b := NewBlocking[int](0)
+
+		start := time.Now()
+		_, ok := b.PollTimeout(10 * time.Millisecond)
+		utils.ValidateResult(t, ok, false)
+
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("Expected PollTimeout to wait at least 10ms but it returned after %v", elapsed)
+		}
+	})
+
+	t.Run("PollTimeout returns an item that arrives in time", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		time.AfterFunc(5*time.Millisecond, func() { b.Offer(7) })
+
+		got, ok := b.PollTimeout(time.Second)
+		utils.ValidateResult(t, ok, true)
+		utils.ValidateResult(t, got, 7)
+	})
+}
+
+func TestBlockingClose(t *testing.T) {
+	t.Run("Wakes blocked takers with ErrClosed", func(t *testing.T) {
+		b := NewBlocking[int](0)
+
+		done := make(chan error)
+		go func() {
+			_, err := b.Take(context.Background())
+			done <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		b.Close()
+
+		if err := <-done; !errors.Is(err, ErrClosed) {
+			t.Errorf("got: %v, want: %v", err, ErrClosed)
+		}
+	})
+
+	t.Run("Wakes blocked putters with ErrClosed", func(t *testing.T) {
+		b := NewBlocking[int](1)
+		b.Put(context.Background(), 1)
+
+		done := make(chan error)
+		go func() { done <- b.Put(context.Background(), 2) }()
+
+		time.Sleep(10 * time.Millisecond)
+		b.Close()
+
+		if err := <-done; !errors.Is(err, ErrClosed) {
+			t.Errorf("got: %v, want: %v", err, ErrClosed)
+		}
+	})
+
+	t.Run("Remaining items can be drained after closing", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		b.Offer(1)
+		b.Close()
+
+		got, err := b.Take(context.Background())
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, got, 1)
+
+		_, err = b.Take(context.Background())
+		utils.ValidateResult(t, err, ErrClosed)
+		utils.ValidateResult(t, b.Offer(2), false)
+	})
+}
+
+func TestBlockingDequeueBatch(t *testing.T) {
+	t.Run("Returns as soon as max items are available", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		for i := 0; i < 5; i++ {
+			b.Offer(i)
+		}
+
+		got, err := b.DequeueBatch(context.Background(), 3, time.Hour)
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, len(got), 3)
+		for i := range got {
+			utils.ValidateResult(t, got[i], i)
+		}
+		utils.ValidateResult(t, b.Len(), 2)
+	})
+
+	t.Run("Returns a partial batch after maxWait", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		b.Offer(1)
+
+		start := time.Now()
+		got, _ := b.DequeueBatch(context.Background(), 3, 20*time.Millisecond)
+
+		utils.ValidateResult(t, len(got), 1)
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("Expected DequeueBatch to wait at least 20ms but it returned after %v", elapsed)
+		}
+	})
+
+	t.Run("Collects items that arrive while waiting", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		go func() {
+			for i := 0; i < 3; i++ {
+				time.Sleep(time.Millisecond)
+				b.Offer(i)
+			}
+		}()
+
+		got, _ := b.DequeueBatch(context.Background(), 3, time.Hour)
+		utils.ValidateResult(t, len(got), 3)
+	})
+
+	t.Run("Waits for the first item", func(t *testing.T) {
+		b := NewBlocking[int](0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		got, err := b.DequeueBatch(ctx, 3, time.Millisecond)
+		utils.ValidateResult(t, len(got), 0)
+		utils.ValidateResult(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Returns a partial batch when closed", func(t *testing.T) {
+		b := NewBlocking[int](0)
+		b.Offer(1)
+		time.AfterFunc(5*time.Millisecond, b.Close)
+
+		got, err := b.DequeueBatch(context.Background(), 3, time.Hour)
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, len(got), 1)
+	})
+}
@@ -0,0 +1,168 @@
+// Package delay implements a queue whose items can only be dequeued once
+// their ready time has passed. Items that become ready at the same instant
+// are dequeued in the order they were enqueued.
+package delay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	priorityqueue "github.com/gyuudon3187/go-data-structures-and-algorithms/priority_queue"
+)
+
+type config struct {
+	clock clock.Clock
+}
+
+type Option func(*config)
+
+// WithClock sets the source of time, which tests can replace to advance time
+// without sleeping.
+func WithClock(clk clock.Clock) Option {
+	return func(c *config) { c.clock = clk }
+}
+
+type entry[T any] struct {
+	item    T
+	readyAt time.Time
+	seq     uint64
+}
+
+func before[T any](a, b entry[T]) bool {
+	if a.readyAt.Equal(b.readyAt) {
+		return a.seq < b.seq
+	}
+
+	return a.readyAt.Before(b.readyAt)
+}
+
+// Handle refers to an enqueued item so that it can be cancelled.
+type Handle[T any] struct {
+	h *priorityqueue.Handle[entry[T]]
+}
+
+type Queue[T any] struct {
+	items   *priorityqueue.PriorityQueue[entry[T]]
+	clock   clock.Clock
+	seq     uint64
+	changed chan struct{}
+	mu      sync.Mutex
+}
+
+func New[T any](opts ...Option) *Queue[T] {
+	c := config{clock: clock.Real{}}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &Queue[T]{
+		items:   priorityqueue.New(before[T]),
+		clock:   c.clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Enqueue hides item until readyAt.
+func (q *Queue[T]) Enqueue(item T, readyAt time.Time) *Handle[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	h := q.items.Push(entry[T]{item: item, readyAt: readyAt, seq: q.seq})
+	q.seq++
+	q.broadcast()
+
+	return &Handle[T]{h}
+}
+
+// Cancel removes the item behind h and reports whether it was still queued.
+// A nil handle refers to nothing, so cancelling it reports false.
+func (q *Queue[T]) Cancel(h *Handle[T]) bool {
+	if h == nil {
+		return false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := q.items.Remove(h.h); err != nil {
+		return false
+	}
+
+	q.broadcast()
+	return true
+}
+
+// Poll dequeues the earliest item if it is ready.
+func (q *Queue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.items.Peek()
+	if !ok || e.readyAt.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+
+	q.items.Pop()
+	return e.item, true
+}
+
+// Take dequeues the earliest item, waiting until it is ready. A timer is set
+// for the earliest ready time, and it is reset whenever an item is enqueued or
+// cancelled.
+func (q *Queue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+
+		e, ok := q.items.Peek()
+		now := q.clock.Now()
+
+		if ok && !e.readyAt.After(now) {
+			q.items.Pop()
+			q.mu.Unlock()
+			return e.item, nil
+		}
+
+		var timer clock.Timer
+		var due <-chan time.Time
+		if ok {
+			timer = q.clock.NewTimer(e.readyAt.Sub(now))
+			due = timer.C()
+		}
+
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-due:
+		case <-changed:
+		case <-ctx.Done():
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// Len counts queued items, whether or not they are ready.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.Len()
+}
+
+func (q *Queue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+func (q *Queue[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
@@ -0,0 +1,186 @@
+package delay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+type testContext struct {
+	clock *clock.Fake
+	queue *Queue[string]
+}
+
+func (c *testContext) beforeEach() {
+	c.clock = clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.queue = New[string](WithClock(c.clock))
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	t.Run("Hides items until they are ready", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Enqueue("later", tc.clock.Now().Add(time.Minute))
+
+		_, ok := tc.queue.Poll()
+		utils.ValidateResult(t, ok, false)
+
+		tc.clock.Advance(time.Minute)
+
+		got, ok := tc.queue.Poll()
+		utils.ValidateResult(t, ok, true)
+		utils.ValidateResult(t, got, "later")
+	}))
+
+	t.Run("Returns items in ready order, then enqueue order", testCase(func(t *testing.T, tc *testContext) {
+		now := tc.clock.Now()
+		tc.queue.Enqueue("c", now.Add(3*time.Second))
+		tc.queue.Enqueue("a", now.Add(time.Second))
+		tc.queue.Enqueue("b1", now.Add(2*time.Second))
+		tc.queue.Enqueue("b2", now.Add(2*time.Second))
+
+		tc.clock.Advance(time.Hour)
+
+		for _, want := range []string{"a", "b1", "b2", "c"} {
+			got, _ := tc.queue.Poll()
+			utils.ValidateResult(t, got, want)
+		}
+	}))
+}
+
+func TestTake(t *testing.T) {
+	t.Run("Wakes when the earliest item is due", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Enqueue("soon", tc.clock.Now().Add(time.Second))
+
+		got := make(chan string)
+		go func() {
+			item, _ := tc.queue.Take(context.Background())
+			got <- item
+		}()
+
+		tc.clock.WaitForTimers(1)
+		tc.clock.Advance(999 * time.Millisecond)
+
+		select {
+		case item := <-got:
+			t.Fatalf("Expected Take to wait for the item but it returned %q early", item)
+		default:
+		}
+
+		tc.clock.Advance(time.Millisecond)
+		utils.ValidateResult(t, <-got, "soon")
+	}))
+
+	t.Run("Rearms for an earlier item enqueued while waiting", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Enqueue("late", tc.clock.Now().Add(time.Hour))
+
+		got := make(chan string)
+		go func() {
+			item, _ := tc.queue.Take(context.Background())
+			got <- item
+		}()
+
+		tc.clock.WaitForTimers(1)
+		tc.queue.Enqueue("early", tc.clock.Now().Add(time.Second))
+		tc.clock.WaitForTimers(1)
+		tc.clock.Advance(time.Second)
+
+		utils.ValidateResult(t, <-got, "early")
+		utils.ValidateResult(t, tc.queue.Len(), 1)
+	}))
+
+	t.Run("Waits for an item when empty", testCase(func(t *testing.T, tc *testContext) {
+		got := make(chan string)
+		go func() {
+			item, _ := tc.queue.Take(context.Background())
+			got <- item
+		}()
+
+		tc.queue.Enqueue("now", tc.clock.Now())
+		utils.ValidateResult(t, <-got, "now")
+	}))
+
+	t.Run("Returns the context error when cancelled", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Enqueue("never", tc.clock.Now().Add(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Millisecond, cancel)
+
+		_, err := tc.queue.Take(ctx)
+		utils.ValidateResult(t, err, context.Canceled)
+		utils.ValidateResult(t, tc.queue.Len(), 1)
+	}))
+}
+
+func TestCancel(t *testing.T) {
+	t.Run("Removes a queued item", testCase(func(t *testing.T, tc *testContext) {
+		h := tc.queue.Enqueue("cancelled", tc.clock.Now())
+		tc.queue.Enqueue("kept", tc.clock.Now())
+
+		utils.ValidateResult(t, tc.queue.Cancel(h), true)
+
+		got, _ := tc.queue.Poll()
+		utils.ValidateResult(t, got, "kept")
+		utils.ValidateResult(t, tc.queue.IsEmpty(), true)
+	}))
+
+	t.Run("Reports an item that was already taken", testCase(func(t *testing.T, tc *testContext) {
+		h := tc.queue.Enqueue("taken", tc.clock.Now())
+		tc.queue.Poll()
+
+		utils.ValidateResult(t, tc.queue.Cancel(h), false)
+	}))
+
+	t.Run("Reports a nil handle", testCase(func(t *testing.T, tc *testContext) {
+		utils.ValidateResult(t, tc.queue.Cancel(nil), false)
+	}))
+
+	t.Run("Wakes a taker waiting on the cancelled item", testCase(func(t *testing.T, tc *testContext) {
+		h := tc.queue.Enqueue("cancelled", tc.clock.Now().Add(time.Second))
+		tc.queue.Enqueue("kept", tc.clock.Now().Add(time.Minute))
+
+		got := make(chan string)
+		go func() {
+			item, _ := tc.queue.Take(context.Background())
+			got <- item
+		}()
+
+		tc.clock.WaitForTimers(1)
+		tc.queue.Cancel(h)
+		tc.clock.Advance(time.Second)
+
+		select {
+		case item := <-got:
+			t.Fatalf("Expected Take to skip the cancelled item but it returned %q", item)
+		default:
+		}
+
+		tc.clock.WaitForTimers(1)
+		tc.clock.Advance(time.Minute)
+		utils.ValidateResult(t, <-got, "kept")
+	}))
+}
+
+func TestRealClock(t *testing.T) {
+	q := New[int]()
+	q.Enqueue(1, time.Now().Add(10*time.Millisecond))
+
+	start := time.Now()
+	got, err := q.Take(context.Background())
+
+	utils.ValidateResult(t, err, nil)
+	utils.ValidateResult(t, got, 1)
+
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected Take to wait at least 10ms but it returned after %v", elapsed)
+	}
+}
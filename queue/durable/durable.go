@@ -0,0 +1,717 @@
+// Package durable implements a FIFO queue that survives process restarts.
+//
+// Items are appended to segment files in a directory. Every record is framed
+// as a 4-byte length and a 4-byte CRC-32C of the payload, followed by the
+// payload itself. The consumer's position is kept in a separate offset file
+// that is replaced atomically. Segments are deleted once an offset past them
+// has been persisted.
+//
+// On Open, a torn or corrupt record at the end of the last segment is
+// truncated away, as it can only be the result of a write interrupted by a
+// crash. Items dequeued after the offset was last persisted are delivered
+// again, so consumers get at-least-once delivery.
+package durable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrEmpty   = errors.New("queue is empty")
+	ErrClosed  = errors.New("queue is closed")
+	ErrCorrupt = errors.New("queue data is corrupt")
+	// ErrDecode means a record was read intact but the codec rejected it. The
+	// record is skipped, so the next Dequeue moves on to the following one.
+	ErrDecode = errors.New("queue record cannot be decoded")
+	// ErrFailed means a write failed in a way that could not be rolled back.
+	// The queue rejects further operations and must be reopened, which
+	// truncates the partial record.
+	ErrFailed = errors.New("queue has failed")
+)
+
+const (
+	headerSize         = 8
+	segmentExt         = ".seg"
+	offsetFile         = "offset"
+	defaultSegmentSize = 64 << 20
+	// defaultSyncInterval replaces a sync interval of zero or less, which
+	// time.NewTicker would reject.
+	defaultSyncInterval = time.Second
+)
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs after every Enqueue and Dequeue.
+	SyncAlways SyncPolicy = iota
+	// SyncBatch fsyncs after every n Enqueue and Dequeue calls.
+	SyncBatch
+	// SyncInterval fsyncs on a timer.
+	SyncInterval
+)
+
+type config struct {
+	segmentSize  int64
+	policy       SyncPolicy
+	batchSize    int
+	syncInterval time.Duration
+}
+
+type Option func(*config)
+
+// WithSegmentSize sets the size in bytes after which a new segment is
+// started. A single record larger than this still fits in one segment.
+func WithSegmentSize(size int64) Option {
+	return func(c *config) { c.segmentSize = size }
+}
+
+func WithSyncAlways() Option {
+	return func(c *config) { c.policy = SyncAlways }
+}
+
+func WithSyncBatch(n int) Option {
+	return func(c *config) {
+		c.policy = SyncBatch
+		c.batchSize = max(n, 1)
+	}
+}
+
+// WithSyncInterval fsyncs every d. An interval of zero or less falls back to
+// one second.
+func WithSyncInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.policy = SyncInterval
+		c.syncInterval = d
+	}
+}
+
+type Queue[T any] struct {
+	dir      string
+	codec    Codec[T]
+	config   config
+	segments []uint64
+	write    *os.File
+	writeEnd int64
+	read     *os.File
+	readPos  int64
+	readEnd  int64
+	// consumed lists the segments the reader has moved past, which are only
+	// deleted once the offset saying so is saved.
+	consumed []uint64
+	len      int
+	unsynced int
+	closed   bool
+	failed   error
+	// syncErr holds the error of a background sync under SyncInterval until
+	// the next operation returns it.
+	syncErr error
+	stop    chan struct{}
+	stopped sync.WaitGroup
+	mu      sync.Mutex
+}
+
+// Open opens the queue stored in dir, creating it if needed, and recovers
+// from an interrupted final write.
+func Open[T any](dir string, codec Codec[T], opts ...Option) (*Queue[T], error) {
+	c := config{segmentSize: defaultSegmentSize, policy: SyncAlways}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.syncInterval <= 0 {
+		c.syncInterval = defaultSyncInterval
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &Queue[T]{dir: dir, codec: codec, config: c, stop: make(chan struct{})}
+
+	if err := q.recover(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+
+	if c.policy == SyncInterval {
+		q.stopped.Add(1)
+		go q.syncPeriodically()
+	}
+
+	return q, nil
+}
+
+func (q *Queue[T]) Enqueue(item T) error {
+	payload, err := q.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, headerSize+len(payload))
+	binary.LittleEndian.PutUint32(record, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], crc32.Checksum(payload, table))
+	copy(record[headerSize:], payload)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	if q.writeEnd > 0 && q.writeEnd+int64(len(record)) > q.config.segmentSize {
+		if err := q.roll(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := writeFile(q.write, record); err != nil {
+		// A partial write leaves bytes that later records would land after,
+		// at offsets the reader does not expect, so they have to go.
+		if truncErr := q.write.Truncate(q.writeEnd); truncErr != nil {
+			q.failed = fmt.Errorf("%w: %w", ErrFailed, errors.Join(err, truncErr))
+			return q.failed
+		}
+
+		return err
+	}
+
+	q.writeEnd += int64(len(record))
+	q.len++
+
+	return q.afterOperation()
+}
+
+// Dequeue returns ErrEmpty when there is nothing to dequeue. A record the
+// codec cannot decode is consumed and reported with an error wrapping
+// ErrDecode.
+func (q *Queue[T]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+
+	if err := q.usable(); err != nil {
+		return zero, err
+	}
+
+	if q.len == 0 {
+		return zero, ErrEmpty
+	}
+
+	payload, next, err := readRecord(q.read, q.readPos, q.readLimit())
+	if errors.Is(err, io.EOF) && len(q.segments) > 1 {
+		if err := q.advance(); err != nil {
+			return zero, err
+		}
+
+		payload, next, err = readRecord(q.read, q.readPos, q.readLimit())
+	}
+
+	if err != nil {
+		return zero, err
+	}
+
+	q.readPos = next
+	q.len--
+
+	item, err := q.codec.Decode(payload)
+	if err != nil {
+		return zero, errors.Join(fmt.Errorf("%w: %w", ErrDecode, err), q.afterOperation())
+	}
+
+	return item, q.afterOperation()
+}
+
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.len
+}
+
+func (q *Queue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+// Sync flushes written items and the consumer offset to stable storage.
+func (q *Queue[T]) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.usable(); err != nil {
+		return err
+	}
+
+	return q.sync()
+}
+
+func (q *Queue[T]) Close() error {
+	q.mu.Lock()
+
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+
+	err := q.syncErr
+	if q.failed == nil {
+		err = errors.Join(err, q.sync())
+	}
+
+	q.closed = true
+	close(q.stop)
+	q.mu.Unlock()
+
+	q.stopped.Wait()
+
+	return errors.Join(err, q.closeFiles())
+}
+
+// usable returns the error that stops the next operation, if any: the queue
+// being closed or failed, or a background sync having failed since the last
+// operation.
+func (q *Queue[T]) usable() error {
+	switch {
+	case q.closed:
+		return ErrClosed
+	case q.failed != nil:
+		return q.failed
+	}
+
+	err := q.syncErr
+	q.syncErr = nil
+	return err
+}
+
+func (q *Queue[T]) afterOperation() error {
+	q.unsynced++
+
+	switch q.config.policy {
+	case SyncAlways:
+		return q.sync()
+	case SyncBatch:
+		if q.unsynced >= q.config.batchSize {
+			return q.sync()
+		}
+	}
+
+	return nil
+}
+
+func (q *Queue[T]) syncPeriodically() {
+	defer q.stopped.Done()
+
+	ticker := time.NewTicker(q.config.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			q.mu.Lock()
+			if q.unsynced > 0 && q.failed == nil {
+				if err := q.sync(); err != nil {
+					q.syncErr = err
+				}
+			}
+			q.mu.Unlock()
+		}
+	}
+}
+
+// sync persists the segment before the offset, so the offset never refers to
+// data that could still be lost.
+func (q *Queue[T]) sync() error {
+	if q.unsynced == 0 {
+		return nil
+	}
+
+	if err := q.write.Sync(); err != nil {
+		return err
+	}
+
+	if err := q.saveOffset(); err != nil {
+		return err
+	}
+
+	q.unsynced = 0
+	return q.removeConsumed()
+}
+
+func (q *Queue[T]) removeConsumed() error {
+	for len(q.consumed) > 0 {
+		if err := os.Remove(q.segmentPath(q.consumed[0])); err != nil {
+			return err
+		}
+		q.consumed = q.consumed[1:]
+	}
+
+	return nil
+}
+
+// roll seals the active segment and starts the next one.
+func (q *Queue[T]) roll() error {
+	if err := q.write.Sync(); err != nil {
+		return err
+	}
+
+	id := q.segments[len(q.segments)-1] + 1
+
+	f, err := os.OpenFile(q.segmentPath(id), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err := syncDir(q.dir); err != nil {
+		f.Close()
+		return err
+	}
+
+	if q.write == q.read {
+		q.readEnd = q.writeEnd
+	} else if err := q.write.Close(); err != nil {
+		f.Close()
+		return err
+	}
+
+	q.write = f
+	q.writeEnd = 0
+	q.segments = append(q.segments, id)
+
+	return nil
+}
+
+// advance moves the reader to the next segment. The consumed one is kept
+// until the next sync saves an offset past it, so that a crash before then
+// still redelivers the items dequeued from it.
+func (q *Queue[T]) advance() error {
+	q.consumed = append(q.consumed, q.segments[0])
+	q.segments = q.segments[1:]
+
+	if err := q.read.Close(); err != nil {
+		return err
+	}
+
+	q.readPos = 0
+
+	if len(q.segments) == 1 {
+		q.read = q.write
+	} else if err := q.openReader(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *Queue[T]) recover() error {
+	ids, err := q.listSegments()
+	if err != nil {
+		return err
+	}
+
+	readID, readPos, err := q.loadOffset()
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		ids = []uint64{readID}
+	}
+
+	if readID < ids[0] {
+		readID, readPos = ids[0], 0
+	}
+
+	for len(ids) > 1 && ids[0] < readID {
+		if err := os.Remove(q.segmentPath(ids[0])); err != nil {
+			return err
+		}
+		ids = ids[1:]
+	}
+
+	if ids[0] != readID {
+		readID, readPos = ids[0], 0
+	}
+
+	for i, id := range ids {
+		isLast := i == len(ids)-1
+
+		from := int64(0)
+		if id == readID {
+			from = readPos
+		}
+
+		count, end, err := q.scanSegment(id, from, isLast)
+		if err != nil {
+			return err
+		}
+
+		q.len += count
+
+		if isLast {
+			q.writeEnd = end
+		}
+	}
+
+	q.segments = ids
+
+	q.write, err = os.OpenFile(q.segmentPath(ids[len(ids)-1]), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 1 {
+		q.read = q.write
+	} else if err := q.openReader(); err != nil {
+		return err
+	}
+
+	q.readPos = min(readPos, q.readLimit())
+
+	return nil
+}
+
+// openReader opens the oldest segment, which is sealed, for reading.
+func (q *Queue[T]) openReader() error {
+	f, err := os.Open(q.segmentPath(q.segments[0]))
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	q.read = f
+	q.readEnd = info.Size()
+
+	return nil
+}
+
+func (q *Queue[T]) readLimit() int64 {
+	if q.read == q.write {
+		return q.writeEnd
+	}
+
+	return q.readEnd
+}
+
+// scanSegment validates every record in a segment and counts those at or
+// after from. A bad tail in the last segment is truncated; anywhere else it
+// is reported as corruption.
+func (q *Queue[T]) scanSegment(id uint64, from int64, isLast bool) (int, int64, error) {
+	path := q.segmentPath(id)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count := 0
+	pos := int64(0)
+	fromIsBoundary := from == 0
+
+	for pos < info.Size() {
+		_, next, err := readRecord(f, pos, info.Size())
+		if err != nil {
+			break
+		}
+
+		if pos >= from {
+			count++
+		}
+
+		pos = next
+		fromIsBoundary = fromIsBoundary || pos == from
+	}
+
+	if pos < info.Size() {
+		if !isLast {
+			return 0, 0, fmt.Errorf("%w: bad record at offset %d of %s", ErrCorrupt, pos, path)
+		}
+
+		if err := f.Truncate(pos); err != nil {
+			return 0, 0, err
+		}
+
+		if err := f.Sync(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if !fromIsBoundary && from < pos {
+		return 0, 0, fmt.Errorf("%w: offset %d of %s is not a record boundary", ErrCorrupt, from, path)
+	}
+
+	return count, pos, nil
+}
+
+func (q *Queue[T]) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExt)
+		if !ok {
+			continue
+		}
+
+		id, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids, nil
+}
+
+// loadOffset reads the consumer position as a segment id and a byte offset,
+// followed by a checksum of both.
+func (q *Queue[T]) loadOffset() (uint64, int64, error) {
+	data, err := os.ReadFile(filepath.Join(q.dir, offsetFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(data) != 20 || crc32.Checksum(data[:16], table) != binary.LittleEndian.Uint32(data[16:]) {
+		return 0, 0, fmt.Errorf("%w: bad offset file", ErrCorrupt)
+	}
+
+	return binary.LittleEndian.Uint64(data), int64(binary.LittleEndian.Uint64(data[8:])), nil
+}
+
+func (q *Queue[T]) saveOffset() error {
+	data := make([]byte, 20)
+	binary.LittleEndian.PutUint64(data, q.segments[0])
+	binary.LittleEndian.PutUint64(data[8:], uint64(q.readPos))
+	binary.LittleEndian.PutUint32(data[16:], crc32.Checksum(data[:16], table))
+
+	path := filepath.Join(q.dir, offsetFile)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	return syncDir(q.dir)
+}
+
+func (q *Queue[T]) closeFiles() error {
+	var err error
+
+	if q.read != nil && q.read != q.write {
+		err = q.read.Close()
+	}
+
+	if q.write != nil {
+		err = errors.Join(err, q.write.Close())
+	}
+
+	return err
+}
+
+func (q *Queue[T]) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+// readRecord reads the record at pos, which must end by limit, and returns
+// its payload and the position of the next record. It returns io.EOF if pos is
+// limit and ErrCorrupt if the record there is incomplete or fails its
+// checksum.
+func readRecord(f *os.File, pos, limit int64) ([]byte, int64, error) {
+	if pos >= limit {
+		return nil, pos, io.EOF
+	}
+
+	header := make([]byte, headerSize)
+
+	if pos+headerSize > limit {
+		return nil, pos, fmt.Errorf("%w: truncated header at offset %d", ErrCorrupt, pos)
+	}
+
+	if _, err := f.ReadAt(header, pos); err != nil {
+		return nil, pos, err
+	}
+
+	size := int64(binary.LittleEndian.Uint32(header))
+
+	if pos+headerSize+size > limit {
+		return nil, pos, fmt.Errorf("%w: truncated record at offset %d", ErrCorrupt, pos)
+	}
+
+	payload := make([]byte, size)
+
+	if _, err := f.ReadAt(payload, pos+headerSize); err != nil {
+		return nil, pos, err
+	}
+
+	if crc32.Checksum(payload, table) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, pos, fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorrupt, pos)
+	}
+
+	return payload, pos + headerSize + int64(len(payload)), nil
+}
+
+// writeFile is the write that Enqueue uses, which tests replace to simulate
+// failures such as a full disk.
+var writeFile = (*os.File).Write
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
@@ -0,0 +1,370 @@
+package durable
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+type job struct {
+	ID   int
+	Name string
+}
+
+type testContext struct {
+	dir   string
+	queue *Queue[job]
+}
+
+func (c *testContext) open(t *testing.T, opts ...Option) {
+	q, err := Open[job](c.dir, JSONCodec[job]{}, opts...)
+	if err != nil {
+		t.Fatalf("Could not open queue: %s", err.Error())
+	}
+
+	c.queue = q
+}
+
+// crash drops the queue without persisting anything that has not been synced
+// yet, as if the process had died.
+func (c *testContext) crash() {
+	c.queue.mu.Lock()
+	c.queue.closed = true
+	close(c.queue.stop)
+	c.queue.mu.Unlock()
+
+	c.queue.stopped.Wait()
+	c.queue.closeFiles()
+}
+
+func testCase(test func(*testing.T, *testContext), opts ...Option) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{dir: t.TempDir()}
+		context.open(t, opts...)
+		test(t, context)
+		context.queue.Close()
+	}
+}
+
+func enqueueJobs(t *testing.T, q *Queue[job], from, to int) {
+	for i := from; i < to; i++ {
+		if err := q.Enqueue(job{i, fmt.Sprintf("job %d", i)}); err != nil {
+			t.Fatalf("Could not enqueue job %d: %s", i, err.Error())
+		}
+	}
+}
+
+func dequeueAll(t *testing.T, q *Queue[job]) []job {
+	var jobs []job
+
+	for {
+		j, err := q.Dequeue()
+		if errors.Is(err, ErrEmpty) {
+			return jobs
+		}
+		if err != nil {
+			t.Fatalf("Could not dequeue: %s", err.Error())
+		}
+
+		jobs = append(jobs, j)
+	}
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+func TestEnqueueAndDequeue(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 10)
+		utils.ValidateResult(t, tc.queue.Len(), 10)
+
+		for i, j := range dequeueAll(t, tc.queue) {
+			utils.ValidateResult(t, j, job{i, fmt.Sprintf("job %d", i)})
+		}
+
+		utils.ValidateResult(t, tc.queue.IsEmpty(), true)
+	}))
+
+	t.Run("Returns ErrEmpty when empty", testCase(func(t *testing.T, tc *testContext) {
+		_, err := tc.queue.Dequeue()
+		utils.ValidateResult(t, err, ErrEmpty)
+	}))
+
+	t.Run("Returns ErrClosed after closing", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Close()
+
+		utils.ValidateResult(t, tc.queue.Enqueue(job{}), ErrClosed)
+		_, err := tc.queue.Dequeue()
+		utils.ValidateResult(t, err, ErrClosed)
+	}))
+
+	t.Run("Works with a gob codec", func(t *testing.T) {
+		q, err := Open[map[string]int](t.TempDir(), GobCodec[map[string]int]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer q.Close()
+
+		q.Enqueue(map[string]int{"a": 1})
+		got, _ := q.Dequeue()
+		utils.ValidateResult(t, got["a"], 1)
+	})
+}
+
+func TestSegments(t *testing.T) {
+	t.Run("Rolls over to new segments", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 50)
+
+		if n := len(segmentFiles(t, tc.dir)); n < 5 {
+			t.Errorf("Expected at least 5 segments but got %d", n)
+		}
+
+		for i, j := range dequeueAll(t, tc.queue) {
+			utils.ValidateResult(t, j.ID, i)
+		}
+	}, WithSegmentSize(128)))
+
+	t.Run("Deletes fully consumed segments", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 50)
+		dequeueAll(t, tc.queue)
+
+		utils.ValidateResult(t, len(segmentFiles(t, tc.dir)), 1)
+	}, WithSegmentSize(128)))
+}
+
+func TestRecovery(t *testing.T) {
+	t.Run("Resumes from the persisted offset", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 20)
+		for i := 0; i < 7; i++ {
+			tc.queue.Dequeue()
+		}
+		tc.queue.Close()
+
+		tc.open(t, WithSegmentSize(128))
+		utils.ValidateResult(t, tc.queue.Len(), 13)
+
+		for i, j := range dequeueAll(t, tc.queue) {
+			utils.ValidateResult(t, j.ID, i+7)
+		}
+	}, WithSegmentSize(128)))
+
+	t.Run("Redelivers items dequeued after the last sync", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 10)
+		tc.queue.Sync()
+		tc.queue.Dequeue()
+		tc.queue.Dequeue()
+		tc.crash()
+
+		tc.open(t)
+		got := dequeueAll(t, tc.queue)
+		utils.ValidateResult(t, len(got), 10)
+		utils.ValidateResult(t, got[0].ID, 0)
+	}, WithSyncBatch(1000)))
+
+	t.Run("Redelivers items dequeued across a segment boundary after the last sync", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 20)
+		tc.queue.Sync()
+		segments := len(segmentFiles(t, tc.dir))
+		for i := 0; i < 10; i++ {
+			tc.queue.Dequeue()
+		}
+		tc.crash()
+
+		utils.ValidateResult(t, len(segmentFiles(t, tc.dir)), segments)
+
+		tc.open(t, WithSegmentSize(128))
+		got := dequeueAll(t, tc.queue)
+		utils.ValidateResult(t, len(got), 20)
+		utils.ValidateResult(t, got[0].ID, 0)
+	}, WithSegmentSize(128), WithSyncBatch(1000)))
+
+	t.Run("Deletes consumed segments once the offset is synced", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 20)
+		for i := 0; i < 10; i++ {
+			tc.queue.Dequeue()
+		}
+		tc.queue.Sync()
+		tc.crash()
+
+		tc.open(t, WithSegmentSize(128))
+		got := dequeueAll(t, tc.queue)
+		utils.ValidateResult(t, len(got), 10)
+		utils.ValidateResult(t, got[0].ID, 10)
+	}, WithSegmentSize(128), WithSyncBatch(1000)))
+
+	t.Run("Persists on close with interval syncing", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 10)
+		tc.queue.Dequeue()
+		tc.queue.Close()
+
+		tc.open(t)
+		utils.ValidateResult(t, tc.queue.Len(), 9)
+	}, WithSyncInterval(time.Hour)))
+
+	t.Run("Falls back to the default interval when given none", testCase(func(t *testing.T, tc *testContext) {
+		utils.ValidateResult(t, tc.queue.config.syncInterval, defaultSyncInterval)
+
+		enqueueJobs(t, tc.queue, 0, 3)
+		tc.queue.Dequeue()
+		tc.queue.Close()
+
+		tc.open(t)
+		utils.ValidateResult(t, tc.queue.Len(), 2)
+	}, WithSyncInterval(0)))
+
+	t.Run("Recovers from torn final writes", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+
+		for round := 0; round < 50; round++ {
+			tc := &testContext{dir: t.TempDir()}
+			tc.open(t, WithSegmentSize(256))
+
+			enqueueJobs(t, tc.queue, 0, 40)
+			consumed := rng.Intn(20)
+			for i := 0; i < consumed; i++ {
+				tc.queue.Dequeue()
+			}
+			tc.crash()
+
+			files := segmentFiles(t, tc.dir)
+			last := files[len(files)-1]
+			info, _ := os.Stat(last)
+			cut := rng.Int63n(info.Size() + 1)
+			if err := os.Truncate(last, cut); err != nil {
+				t.Fatal(err)
+			}
+
+			tc.open(t, WithSegmentSize(256))
+			got := dequeueAll(t, tc.queue)
+
+			for i, j := range got {
+				want := job{consumed + i, fmt.Sprintf("job %d", consumed+i)}
+				if j != want {
+					t.Fatalf("round %d: got: %v, want: %v", round, j, want)
+				}
+			}
+
+			if len(got) > 40-consumed {
+				t.Fatalf("round %d: recovered %d items but only %d were left", round, len(got), 40-consumed)
+			}
+
+			enqueueJobs(t, tc.queue, 100, 101)
+			j, _ := tc.queue.Dequeue()
+			utils.ValidateResult(t, j.ID, 100)
+
+			tc.queue.Close()
+		}
+	})
+
+	t.Run("Reports corruption outside the last segment", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 20)
+		tc.queue.Close()
+
+		first := segmentFiles(t, tc.dir)[0]
+		info, _ := os.Stat(first)
+		os.Truncate(first, info.Size()-1)
+
+		_, err := Open[job](tc.dir, JSONCodec[job]{}, WithSegmentSize(128))
+		if !errors.Is(err, ErrCorrupt) {
+			t.Errorf("got: %v, want: %v", err, ErrCorrupt)
+		}
+	}, WithSegmentSize(128)))
+}
+
+func TestFailures(t *testing.T) {
+	t.Run("Drops a partially written record", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 1)
+
+		writeFile = func(f *os.File, b []byte) (int, error) {
+			n, _ := f.Write(b[:len(b)/2])
+			return n, errors.New("no space left on device")
+		}
+		err := tc.queue.Enqueue(job{1, "job 1"})
+		writeFile = (*os.File).Write
+
+		if err == nil {
+			t.Fatal("Enqueue succeeded despite the failed write")
+		}
+
+		enqueueJobs(t, tc.queue, 2, 3)
+		got := dequeueAll(t, tc.queue)
+		want := []job{{0, "job 0"}, {2, "job 2"}}
+		utils.ValidateResult(t, fmt.Sprint(got), fmt.Sprint(want))
+	}))
+
+	t.Run("Fails when a partial write cannot be rolled back", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.write.Close()
+
+		err := tc.queue.Enqueue(job{})
+		if !errors.Is(err, ErrFailed) {
+			t.Fatalf("got: %v, want: %v", err, ErrFailed)
+		}
+
+		_, err = tc.queue.Dequeue()
+		if !errors.Is(err, ErrFailed) {
+			t.Errorf("got: %v, want: %v", err, ErrFailed)
+		}
+	}))
+
+	t.Run("Skips a record that cannot be decoded", func(t *testing.T) {
+		dir := t.TempDir()
+
+		names, err := Open[string](dir, JSONCodec[string]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names.Enqueue("not a job")
+		names.Close()
+
+		tc := &testContext{dir: dir}
+		tc.open(t)
+		defer tc.queue.Close()
+		enqueueJobs(t, tc.queue, 0, 1)
+
+		_, err = tc.queue.Dequeue()
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("got: %v, want: %v", err, ErrDecode)
+		}
+
+		got, err := tc.queue.Dequeue()
+		utils.ValidateResult(t, err, nil)
+		utils.ValidateResult(t, got, job{0, "job 0"})
+	})
+
+	t.Run("Returns a failed background sync from the next operation", testCase(func(t *testing.T, tc *testContext) {
+		enqueueJobs(t, tc.queue, 0, 1)
+
+		tc.queue.mu.Lock()
+		tc.queue.write.Close()
+		tc.queue.mu.Unlock()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			tc.queue.mu.Lock()
+			failed := tc.queue.syncErr != nil
+			tc.queue.mu.Unlock()
+
+			if failed || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		err := tc.queue.Sync()
+		if !errors.Is(err, os.ErrClosed) {
+			t.Errorf("got: %v, want: %v", err, os.ErrClosed)
+		}
+	}, WithSyncInterval(time.Millisecond)))
+}
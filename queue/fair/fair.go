@@ -0,0 +1,159 @@
+// Package fair implements a queue that keeps one FIFO per key and shares
+// Dequeue between the keys in proportion to their weights, so that one busy
+// key cannot starve the others.
+//
+// Keys take turns in round-robin order. With New, a key's turn lasts for as
+// many items as its weight. With NewDeficit, every turn adds quantum times the
+// key's weight to the key's deficit, and the turn lasts while the deficit
+// covers the cost of the key's next item, which shares Dequeue by cost rather
+// than by item count. A key whose FIFO runs empty leaves the rotation and
+// forfeits its deficit, so idle keys do not save up credit.
+//
+// A key is forgotten once its FIFO runs empty, unless it was given a weight
+// other than 1, which it keeps until the weight is set back to 1.
+package fair
+
+import (
+	"sync"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+)
+
+type tenant[K comparable, T any] struct {
+	key     K
+	items   *queue.Queue[T]
+	weight  int
+	deficit int
+	inTurn  bool
+	active  bool
+}
+
+type Queue[K comparable, T any] struct {
+	tenants map[K]*tenant[K, T]
+	active  *queue.Queue[*tenant[K, T]]
+	quantum int
+	cost    func(T) int
+	len     int
+	mu      sync.Mutex
+}
+
+// New creates a weighted round-robin queue.
+func New[K comparable, T any]() *Queue[K, T] {
+	return NewDeficit[K](1, func(T) int { return 1 })
+}
+
+// NewDeficit creates a deficit round-robin queue, where cost reports the
+// size of an item and quantum is the budget each turn adds per unit of weight.
+func NewDeficit[K comparable, T any](quantum int, cost func(T) int) *Queue[K, T] {
+	return &Queue[K, T]{
+		tenants: make(map[K]*tenant[K, T]),
+		active:  queue.NewOf[*tenant[K, T]](),
+		quantum: max(quantum, 1),
+		cost:    cost,
+	}
+}
+
+// SetWeight sets the weight of key, which defaults to 1. Weights below 1 are
+// raised to 1.
+func (q *Queue[K, T]) SetWeight(key K, weight int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenant(key)
+	t.weight = max(weight, 1)
+	q.forgetIfIdle(t)
+}
+
+func (q *Queue[K, T]) Enqueue(key K, item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenant(key)
+	t.items.Enqueue(item)
+	q.len++
+
+	if !t.active {
+		t.active = true
+		q.active.Enqueue(t)
+	}
+}
+
+func (q *Queue[K, T]) Dequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		t, ok := q.active.TryPeek()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+
+		if !t.inTurn {
+			t.inTurn = true
+			t.deficit += q.quantum * t.weight
+		}
+
+		next, _ := t.items.TryPeek()
+
+		if cost := q.cost(next); cost <= t.deficit {
+			t.items.TryDequeue()
+			t.deficit -= cost
+			q.len--
+
+			if t.items.Len() == 0 {
+				t.deficit = 0
+				t.inTurn = false
+				t.active = false
+				q.active.TryDequeue()
+				q.forgetIfIdle(t)
+			}
+
+			return next, true
+		}
+
+		t.inTurn = false
+		q.active.TryDequeue()
+		q.active.Enqueue(t)
+	}
+}
+
+// Len counts the items queued under key.
+func (q *Queue[K, T]) Len(key K) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if t, ok := q.tenants[key]; ok {
+		return t.items.Len()
+	}
+
+	return 0
+}
+
+func (q *Queue[K, T]) TotalLen() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.len
+}
+
+func (q *Queue[K, T]) IsEmpty() bool { return q.TotalLen() == 0 }
+
+func (q *Queue[K, T]) tenant(key K) *tenant[K, T] {
+	t, ok := q.tenants[key]
+
+	if !ok {
+		t = &tenant[K, T]{key: key, items: queue.NewOf[T](), weight: 1}
+		q.tenants[key] = t
+	}
+
+	return t
+}
+
+// forgetIfIdle drops t when nothing would be lost, so keys that come and go
+// do not accumulate.
+func (q *Queue[K, T]) forgetIfIdle(t *tenant[K, T]) {
+	if t.items.Len() == 0 && t.weight == 1 {
+		delete(q.tenants, t.key)
+	}
+}
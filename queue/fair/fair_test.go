@@ -0,0 +1,187 @@
+package fair
+
+import (
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+type request struct {
+	tenant string
+	size   int
+}
+
+func fill(q *Queue[string, request], tenant string, size, n int) {
+	for i := 0; i < n; i++ {
+		q.Enqueue(tenant, request{tenant, size})
+	}
+}
+
+func TestDequeue(t *testing.T) {
+	t.Run("Returns each key's items in FIFO order", func(t *testing.T) {
+		q := New[string, int]()
+		for i := 0; i < 5; i++ {
+			q.Enqueue("a", i)
+		}
+
+		for want := 0; want < 5; want++ {
+			got, _ := q.Dequeue()
+			utils.ValidateResult(t, got, want)
+		}
+
+		_, ok := q.Dequeue()
+		utils.ValidateResult(t, ok, false)
+	})
+
+	t.Run("Alternates between keys of equal weight", func(t *testing.T) {
+		q := New[string, request]()
+		fill(q, "noisy", 1, 100)
+		fill(q, "quiet", 1, 2)
+
+		var got []string
+		for i := 0; i < 4; i++ {
+			r, _ := q.Dequeue()
+			got = append(got, r.tenant)
+		}
+
+		want := []string{"noisy", "quiet", "noisy", "quiet"}
+		for i := range want {
+			utils.ValidateResult(t, got[i], want[i])
+		}
+	})
+
+	t.Run("Skips keys that ran empty", func(t *testing.T) {
+		q := New[string, request]()
+		fill(q, "a", 1, 1)
+		fill(q, "b", 1, 3)
+
+		for i := 0; i < 4; i++ {
+			_, ok := q.Dequeue()
+			utils.ValidateResult(t, ok, true)
+		}
+
+		utils.ValidateResult(t, q.IsEmpty(), true)
+	})
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	q := New[string, request]()
+	weights := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	for tenant, weight := range weights {
+		q.SetWeight(tenant, weight)
+		fill(q, tenant, 1, 10000)
+	}
+
+	served := map[string]int{}
+	for i := 0; i < 6000; i++ {
+		r, _ := q.Dequeue()
+		served[r.tenant]++
+	}
+
+	for tenant, weight := range weights {
+		utils.ValidateResult(t, served[tenant], 1000*weight)
+	}
+}
+
+func TestDeficitRoundRobin(t *testing.T) {
+	t.Run("Shares by cost rather than item count", func(t *testing.T) {
+		q := NewDeficit[string](100, func(r request) int { return r.size })
+		fill(q, "large", 100, 10000)
+		fill(q, "small", 25, 10000)
+
+		served := map[string]int{}
+		for i := 0; i < 5000; i++ {
+			r, _ := q.Dequeue()
+			served[r.tenant] += r.size
+		}
+
+		ratio := float64(served["large"]) / float64(served["small"])
+		if ratio < 0.95 || ratio > 1.05 {
+			t.Errorf("Expected equal cost shares but got %v to %v", served["large"], served["small"])
+		}
+	})
+
+	t.Run("Honours weights", func(t *testing.T) {
+		q := NewDeficit[string](50, func(r request) int { return r.size })
+		q.SetWeight("heavy", 3)
+		fill(q, "heavy", 40, 10000)
+		fill(q, "light", 40, 10000)
+
+		served := map[string]int{}
+		for i := 0; i < 4000; i++ {
+			r, _ := q.Dequeue()
+			served[r.tenant] += r.size
+		}
+
+		ratio := float64(served["heavy"]) / float64(served["light"])
+		if ratio < 2.8 || ratio > 3.2 {
+			t.Errorf("Expected a 3:1 cost share but got %v to %v", served["heavy"], served["light"])
+		}
+	})
+
+	t.Run("Does not let idle keys save up credit", func(t *testing.T) {
+		q := NewDeficit[string](100, func(r request) int { return r.size })
+		fill(q, "steady", 100, 100)
+		fill(q, "bursty", 10, 1)
+
+		for i := 0; i < 20; i++ {
+			q.Dequeue()
+		}
+
+		fill(q, "bursty", 100, 10)
+
+		var got []string
+		for i := 0; i < 4; i++ {
+			r, _ := q.Dequeue()
+			got = append(got, r.tenant)
+		}
+
+		for i := 0; i < len(got)-1; i++ {
+			if got[i] == "bursty" && got[i+1] == "bursty" {
+				t.Errorf("Expected bursty to get one item per turn but got %v", got)
+			}
+		}
+	})
+}
+
+func TestLen(t *testing.T) {
+	q := New[string, int]()
+	q.Enqueue("a", 1)
+	q.Enqueue("a", 2)
+	q.Enqueue("b", 3)
+
+	utils.ValidateResult(t, q.Len("a"), 2)
+	utils.ValidateResult(t, q.Len("b"), 1)
+	utils.ValidateResult(t, q.Len("c"), 0)
+	utils.ValidateResult(t, q.TotalLen(), 3)
+}
+
+func TestForgetsIdleKeys(t *testing.T) {
+	t.Run("Forgets a key once it runs empty", func(t *testing.T) {
+		q := New[int, int]()
+		for i := 0; i < 100; i++ {
+			q.Enqueue(i, i)
+			q.Dequeue()
+		}
+
+		utils.ValidateResult(t, len(q.tenants), 0)
+	})
+
+	t.Run("Keeps the weight of an empty key", func(t *testing.T) {
+		q := New[string, int]()
+		q.SetWeight("a", 3)
+		q.Enqueue("a", 1)
+		q.Dequeue()
+
+		utils.ValidateResult(t, q.tenants["a"].weight, 3)
+	})
+
+	t.Run("Forgets an empty key set back to the default weight", func(t *testing.T) {
+		q := New[string, int]()
+		q.SetWeight("a", 3)
+		q.SetWeight("a", 1)
+
+		utils.ValidateResult(t, len(q.tenants), 0)
+	})
+}
@@ -0,0 +1,119 @@
+// Package lockfree implements the Michael–Scott non-blocking FIFO queue.
+//
+// The queue always holds a dummy node at its head. Enqueue links a node after
+// the current tail and then swings the tail forward; Dequeue swings the head to
+// its successor, which becomes the new dummy. A tail that lags behind is helped
+// forward by whichever goroutine notices it, so no operation waits on another.
+package lockfree
+
+import "sync/atomic"
+
+type node[T any] struct {
+	item T
+	next atomic.Pointer[node[T]]
+}
+
+type Queue[T any] struct {
+	head atomic.Pointer[node[T]]
+	tail atomic.Pointer[node[T]]
+	len  atomic.Int64
+}
+
+func New[T any]() *Queue[T] {
+	q := new(Queue[T])
+	dummy := new(node[T])
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+func (q *Queue[T]) Enqueue(item T) {
+	n := &node[T]{item: item}
+
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+
+		if tail != q.tail.Load() {
+			continue
+		}
+
+		if next != nil {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+func (q *Queue[T]) Dequeue() T {
+	item, _ := q.TryDequeue()
+	return item
+}
+
+// TryDequeue removes the first item. The node holding it becomes the new
+// dummy, so the item stays reachable until the next successful dequeue.
+func (q *Queue[T]) TryDequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		item := next.item
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			return item, true
+		}
+	}
+}
+
+func (q *Queue[T]) Peek() T {
+	item, _ := q.TryPeek()
+	return item
+}
+
+func (q *Queue[T]) TryPeek() (T, bool) {
+	next := q.head.Load().next.Load()
+
+	if next == nil {
+		var zero T
+		return zero, false
+	}
+
+	return next.item, true
+}
+
+// Len is exact when the queue is quiescent and approximate while operations
+// are in flight.
+func (q *Queue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
+
+func (q *Queue[T]) IsEmpty() bool { return q.head.Load().next.Load() == nil }
+
+func (q *Queue[T]) Clear() {
+	for {
+		if _, ok := q.TryDequeue(); !ok {
+			return
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package lockfree
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	mutexqueue "github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []interface{}{1, "string"}
+
+type testContext struct {
+	queue *Queue[interface{}]
+}
+
+func (c *testContext) beforeEach() {
+	q := New[interface{}]()
+
+	for _, item := range items {
+		q.Enqueue(item)
+	}
+
+	c.queue = q
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Run("Enqueue links items after the dummy head in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		nthQueueItem := c.queue.head.Load().next.Load()
+
+		for i := 0; i < len(items); i++ {
+			utils.ValidateResult(t, nthQueueItem.item, items[i])
+			nthQueueItem = nthQueueItem.next.Load()
+		}
+
+		if nthQueueItem != nil {
+			t.Errorf("Expected nthQueueItem to be nil but got %v", nthQueueItem)
+		}
+	}))
+}
+
+func TestTryDequeue(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		for i := range items {
+			got, ok := c.queue.TryDequeue()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[i])
+		}
+
+		_, ok := c.queue.TryDequeue()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Distinguishes a stored nil from an empty queue", func(t *testing.T) {
+		q := New[interface{}]()
+		q.Enqueue(nil)
+
+		_, ok := q.TryDequeue()
+		utils.ValidateResult(t, ok, true)
+
+		_, ok = q.TryDequeue()
+		utils.ValidateResult(t, ok, false)
+	})
+}
+
+func TestPeek(t *testing.T) {
+	t.Run("Returns the first item without removing it", testCase(func(t *testing.T, c *testContext) {
+		utils.ValidateResult(t, c.queue.Peek(), items[0])
+		utils.ValidateResult(t, c.queue.Len(), len(items))
+	}))
+}
+
+func TestLenIsEmptyAndClear(t *testing.T) {
+	t.Run("Len counts enqueued items", testCase(func(t *testing.T, c *testContext) {
+		utils.ValidateResult(t, c.queue.Len(), len(items))
+		utils.ValidateResult(t, c.queue.IsEmpty(), false)
+	}))
+
+	t.Run("Clear empties the queue", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Clear()
+		utils.ValidateResult(t, c.queue.Len(), 0)
+		utils.ValidateResult(t, c.queue.IsEmpty(), true)
+	}))
+}
+
+type tagged struct {
+	producer, seq int
+}
+
+// TestConcurrent checks the properties every linearizable FIFO history has:
+// nothing is lost or duplicated, and each consumer sees the items of any single
+// producer in the order they were enqueued.
+func TestConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 5000
+
+	q := New[tagged]()
+	taken := make([][]tagged, consumers)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue(tagged{p, i})
+			}
+		}()
+	}
+
+	var remaining sync.WaitGroup
+	remaining.Add(producers * perProducer)
+	done := make(chan struct{})
+	go func() {
+		remaining.Wait()
+		close(done)
+	}()
+
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func() {
+			defer consumed.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				if item, ok := q.TryDequeue(); ok {
+					taken[c] = append(taken[c], item)
+					remaining.Done()
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	consumed.Wait()
+
+	seen := make(map[tagged]bool)
+	for c, history := range taken {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+
+		for _, item := range history {
+			if seen[item] {
+				t.Fatalf("Item %v was dequeued twice", item)
+			}
+			seen[item] = true
+
+			if item.seq <= last[item.producer] {
+				t.Fatalf("Consumer %d saw item %d of producer %d after item %d", c, item.seq, item.producer, last[item.producer])
+			}
+			last[item.producer] = item.seq
+		}
+	}
+
+	utils.ValidateResult(t, len(seen), producers*perProducer)
+	utils.ValidateResult(t, q.IsEmpty(), true)
+	utils.ValidateResult(t, q.Len(), 0)
+}
+
+type fifo interface {
+	Enqueue(int)
+	TryDequeue() (int, bool)
+}
+
+func benchmarkFIFO(b *testing.B, q fifo) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				q.Enqueue(i)
+			} else {
+				q.TryDequeue()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkEnqueueDequeue(b *testing.B) {
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("mutex/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			benchmarkFIFO(b, mutexqueue.NewOf[int]())
+		})
+
+		b.Run(fmt.Sprintf("lockfree/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			benchmarkFIFO(b, New[int]())
+		})
+	}
+}
@@ -1,44 +1,194 @@
 package queue
 
-import "sync"
+import (
+	"sync"
 
-type node struct {
-	item interface{}
-	prev *node
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/metrics"
+)
+
+type node[T any] struct {
+	item T
+	prev *node[T]
+}
+
+type options struct {
+	observer metrics.Observer
+}
+
+type Option func(*options)
+
+// WithObserver reports every Enqueue and Dequeue to o.
+func WithObserver(o metrics.Observer) Option {
+	return func(opts *options) { opts.observer = o }
+}
+
+// Queue is a FIFO queue that is safe for concurrent use.
+type Queue[T any] struct {
+	first   *node[T]
+	last    *node[T]
+	len     int
+	tracker *metrics.Tracker[*node[T]]
+	mu      sync.Mutex
+}
+
+// New creates a queue of arbitrary items, as before the queue was generic.
+// Use NewOf for a queue of a single item type.
+func New(opts ...Option) *Queue[any] {
+	return NewOf[any](opts...)
 }
 
-type queue struct {
-	first *node
-	last  *node
-	mu    sync.Mutex
+func NewOf[T any](opts ...Option) *Queue[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Queue[T]{tracker: metrics.NewTracker[*node[T]](o.observer)}
+}
+
+func (q *Queue[T]) Enqueue(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.enqueue(item)
 }
 
-func New() *queue {
-	return new(queue)
+func (q *Queue[T]) Dequeue() T {
+	item, _ := q.TryDequeue()
+	return item
 }
 
-func (q *queue) Enqueue(item interface{}) {
+func (q *Queue[T]) TryDequeue() (T, bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	return q.dequeue()
+}
+
+// DequeueN removes up to n items under a single lock.
+func (q *Queue[T]) DequeueN(n int) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dequeueN(n)
+}
+
+// DrainTo fills dst with as many items as fit and returns how many it
+// dequeued.
+func (q *Queue[T]) DrainTo(dst []T) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i := 0
+	for ; i < len(dst); i++ {
+		item, ok := q.dequeue()
+		if !ok {
+			break
+		}
+
+		dst[i] = item
+	}
+
+	return i
+}
+
+func (q *Queue[T]) DrainAll() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dequeueN(q.len)
+}
+
+func (q *Queue[T]) Peek() T {
+	item, _ := q.TryPeek()
+	return item
+}
+
+func (q *Queue[T]) TryPeek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.first == nil {
+		var zero T
+		return zero, false
+	}
+
+	return q.first.item, true
+}
+
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.len
+}
+
+func (q *Queue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.clear()
+}
+
+func (q *Queue[T]) enqueue(item T) {
+	added := &node[T]{item: item}
+
 	if q.first == nil {
-		q.first = &node{item: item}
+		q.first = added
 		q.last = q.first
 	} else {
-		q.last.prev = &node{item: item}
+		q.last.prev = added
 		q.last = q.last.prev
 	}
+
+	q.len++
+	q.tracker.Inserted(added, q.len)
 }
 
-func (q *queue) Dequeue() interface{} {
-	q.mu.Lock()
-	defer q.mu.Unlock()
+func (q *Queue[T]) dequeue() (T, bool) {
+	if q.first == nil {
+		var zero T
+		return zero, false
+	}
+
+	removed := q.first
+	q.first = removed.prev
+	removed.prev = nil
+
+	if q.first == nil {
+		q.last = nil
+	}
+
+	q.len--
+	q.tracker.Removed(removed, q.len)
+
+	return removed.item, true
+}
+
+func (q *Queue[T]) dequeueN(n int) []T {
+	items := make([]T, 0, max(min(n, q.len), 0))
+
+	for len(items) < n {
+		item, ok := q.dequeue()
+		if !ok {
+			break
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
 
-	if q.first != nil {
-		item := q.first.item
-		q.first = q.first.prev
-		return item
+func (q *Queue[T]) clear() {
+	if q.tracker != nil {
+		q.dequeueN(q.len)
+		return
 	}
 
-	return nil
+	q.first = nil
+	q.last = nil
+	q.len = 0
 }
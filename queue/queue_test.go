@@ -3,6 +3,7 @@ package queue
 import (
 	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
 	"testing"
+	"time"
 )
 
 var items = []interface{}{1, "string"}
@@ -12,12 +13,12 @@ type TestContext interface {
 }
 
 type testContext struct {
-	queue          *queue
+	queue          *Queue[interface{}]
 	itemsLastIndex int
 }
 
 func (c *testContext) beforeEach() {
-	q := new(queue)
+	q := New()
 
 	for _, item := range items {
 		q.Enqueue(item)
@@ -64,4 +65,206 @@ func TestDequeue(t *testing.T) {
 			utils.ValidateResult(t, got, want)
 		}
 	}))
+
+	t.Run("Clears the last pointer when the queue becomes empty", testCase(func(t *testing.T, c *testContext) {
+		for range items {
+			c.queue.Dequeue()
+		}
+
+		if c.queue.last != nil {
+			t.Errorf("Expected last to be nil but got %v", c.queue.last)
+		}
+	}))
+
+	t.Run("Returns nil when empty", func(t *testing.T) {
+		q := New()
+		got := q.Dequeue()
+		var want interface{} = nil
+		utils.ValidateResult(t, got, want)
+	})
+
+	t.Run("Decrements length", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Dequeue()
+		got := c.queue.Len()
+		want := len(items) - 1
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestTryDequeue(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		for i := range items {
+			got, ok := c.queue.TryDequeue()
+			if !ok {
+				t.Fatalf("Expected item %d to be dequeued but the queue was empty", i)
+			}
+
+			want := items[i]
+			utils.ValidateResult(t, got, want)
+		}
+	}))
+
+	t.Run("Distinguishes a stored nil from an empty queue", func(t *testing.T) {
+		q := New()
+		q.Enqueue(nil)
+
+		_, ok := q.TryDequeue()
+		utils.ValidateResult(t, ok, true)
+
+		_, ok = q.TryDequeue()
+		utils.ValidateResult(t, ok, false)
+	})
+}
+
+func TestPeek(t *testing.T) {
+	t.Run("Returns the first item without removing it", testCase(func(t *testing.T, c *testContext) {
+		var got interface{}
+
+		for i := 0; i < 2; i++ {
+			got = c.queue.Peek()
+		}
+
+		want := items[0]
+		utils.ValidateResult(t, got, want)
+		utils.ValidateResult(t, c.queue.Len(), len(items))
+	}))
+
+	t.Run("Reports an empty queue", func(t *testing.T) {
+		q := New()
+		_, ok := q.TryPeek()
+		utils.ValidateResult(t, ok, false)
+	})
+}
+
+func TestLen(t *testing.T) {
+	t.Run("Counts enqueued items", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.Len()
+		want := len(items)
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestIsEmpty(t *testing.T) {
+	t.Run("True when empty", func(t *testing.T) {
+		q := New()
+		got := q.IsEmpty()
+		want := true
+		utils.ValidateResult(t, got, want)
+	})
+
+	t.Run("False when not empty", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.IsEmpty()
+		want := false
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestClear(t *testing.T) {
+	t.Run("Removes all items", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Clear()
+
+		utils.ValidateResult(t, c.queue.Len(), 0)
+		utils.ValidateResult(t, c.queue.first, (*node[interface{}])(nil))
+		utils.ValidateResult(t, c.queue.last, (*node[interface{}])(nil))
+	}))
+
+	t.Run("Queue is usable after clearing", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Clear()
+		c.queue.Enqueue("foo")
+
+		got := c.queue.Dequeue()
+		want := "foo"
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestDequeueN(t *testing.T) {
+	t.Run("Returns up to n items in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.DequeueN(1)
+		utils.ValidateResult(t, len(got), 1)
+		utils.ValidateResult(t, got[0], items[0])
+		utils.ValidateResult(t, c.queue.Len(), len(items)-1)
+	}))
+
+	t.Run("Stops when the queue runs out", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.DequeueN(len(items) + 5)
+		utils.ValidateResult(t, len(got), len(items))
+		utils.ValidateResult(t, c.queue.IsEmpty(), true)
+	}))
+}
+
+func TestDrainTo(t *testing.T) {
+	t.Run("Fills the destination", testCase(func(t *testing.T, c *testContext) {
+		dst := make([]interface{}, 1)
+		got := c.queue.DrainTo(dst)
+
+		utils.ValidateResult(t, got, 1)
+		utils.ValidateResult(t, dst[0], items[0])
+	}))
+
+	t.Run("Reports how many items were drained", testCase(func(t *testing.T, c *testContext) {
+		dst := make([]interface{}, len(items)+1)
+		got := c.queue.DrainTo(dst)
+
+		utils.ValidateResult(t, got, len(items))
+		utils.ValidateResult(t, dst[len(items)], nil)
+	}))
+}
+
+func TestDrainAll(t *testing.T) {
+	t.Run("Returns every item in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.DrainAll()
+
+		utils.ValidateResult(t, len(got), len(items))
+		for i := range items {
+			utils.ValidateResult(t, got[i], items[i])
+		}
+		utils.ValidateResult(t, c.queue.IsEmpty(), true)
+	}))
+}
+
+type event struct {
+	inserted bool
+	size     int
+}
+
+type recordingObserver struct {
+	events []event
+}
+
+func (o *recordingObserver) OnInsert(size int) {
+	o.events = append(o.events, event{true, size})
+}
+
+func (o *recordingObserver) OnRemove(size int, waited time.Duration) {
+	o.events = append(o.events, event{false, size})
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("Reports enqueues and dequeues with the resulting size", func(t *testing.T) {
+		o := &recordingObserver{}
+		q := NewOf[int](WithObserver(o))
+
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Dequeue()
+		q.Clear()
+
+		want := []event{{true, 1}, {true, 2}, {false, 1}, {false, 0}}
+		utils.ValidateResult(t, len(o.events), len(want))
+		for i := range want {
+			utils.ValidateResult(t, o.events[i], want[i])
+		}
+	})
+
+	t.Run("Costs no extra allocations when absent", func(t *testing.T) {
+		q := NewOf[int]()
+
+		allocs := testing.AllocsPerRun(100, func() {
+			q.Enqueue(1)
+			q.Dequeue()
+		})
+
+		utils.ValidateResult(t, allocs, float64(1))
+	})
 }
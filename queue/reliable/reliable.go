@@ -0,0 +1,241 @@
+// Package reliable implements an in-process queue with at-least-once
+// delivery, modelled on Amazon SQS.
+//
+// Receive hands out an item together with a receipt and hides the item for a
+// visibility timeout. Ack deletes it for good. Nack, or letting the timeout
+// expire, makes it receivable again at the back of the queue. An item that
+// keeps coming back can be moved to a dead-letter queue after a maximum number
+// of receives.
+package reliable
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	priorityqueue "github.com/gyuudon3187/go-data-structures-and-algorithms/priority_queue"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+)
+
+var ErrInvalidReceipt = errors.New("receipt does not refer to an item in flight")
+
+const defaultVisibilityTimeout = 30 * time.Second
+
+type config struct {
+	clock             clock.Clock
+	visibilityTimeout time.Duration
+	maxReceives       int
+}
+
+type Option func(*config)
+
+// WithClock sets the source of time, which tests can replace to advance time
+// without sleeping.
+func WithClock(clk clock.Clock) Option {
+	return func(c *config) { c.clock = clk }
+}
+
+// WithVisibilityTimeout sets how long a received item stays hidden before it
+// is delivered again. The default is 30 seconds.
+func WithVisibilityTimeout(d time.Duration) Option {
+	return func(c *config) { c.visibilityTimeout = d }
+}
+
+// WithMaxReceives moves an item to the dead-letter queue instead of making it
+// receivable again once it has been received n times. Zero, the default,
+// never gives up on an item.
+func WithMaxReceives(n int) Option {
+	return func(c *config) { c.maxReceives = n }
+}
+
+// Receipt identifies one delivery of an item. The zero Receipt, which comes
+// with the empty Message of an unsuccessful Receive, refers to no delivery.
+type Receipt struct {
+	id uint64
+}
+
+type Message[T any] struct {
+	Item    T
+	Receipt Receipt
+	// Receives counts how many times the item has been received, including
+	// this time.
+	Receives int
+}
+
+type envelope[T any] struct {
+	item     T
+	receives int
+}
+
+type inFlight[T any] struct {
+	envelope *envelope[T]
+	receipt  Receipt
+	deadline time.Time
+}
+
+type Queue[T any] struct {
+	ready       *queue.Queue[*envelope[T]]
+	deadLetters *queue.Queue[T]
+	inFlight    *priorityqueue.PriorityQueue[inFlight[T]]
+	receipts    map[Receipt]*priorityqueue.Handle[inFlight[T]]
+	nextReceipt uint64
+	config      config
+	mu          sync.Mutex
+}
+
+func New[T any](opts ...Option) *Queue[T] {
+	c := config{clock: clock.Real{}, visibilityTimeout: defaultVisibilityTimeout}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &Queue[T]{
+		ready:       queue.NewOf[*envelope[T]](),
+		deadLetters: queue.NewOf[T](),
+		inFlight: priorityqueue.New(func(a, b inFlight[T]) bool {
+			if a.deadline.Equal(b.deadline) {
+				return a.receipt.id < b.receipt.id
+			}
+			return a.deadline.Before(b.deadline)
+		}),
+		receipts: make(map[Receipt]*priorityqueue.Handle[inFlight[T]]),
+		config:   c,
+	}
+}
+
+func (q *Queue[T]) Enqueue(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.ready.Enqueue(&envelope[T]{item: item})
+}
+
+// Receive hands out the first receivable item and hides it for the
+// visibility timeout.
+func (q *Queue[T]) Receive() (Message[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.config.clock.Now()
+	q.expire(now)
+
+	e, ok := q.ready.TryDequeue()
+	if !ok {
+		return Message[T]{}, false
+	}
+
+	e.receives++
+	q.nextReceipt++
+	receipt := Receipt{q.nextReceipt}
+
+	q.receipts[receipt] = q.inFlight.Push(inFlight[T]{
+		envelope: e,
+		receipt:  receipt,
+		deadline: now.Add(q.config.visibilityTimeout),
+	})
+
+	return Message[T]{Item: e.item, Receipt: receipt, Receives: e.receives}, true
+}
+
+// Ack deletes the item behind receipt. It fails once the visibility timeout
+// has expired, since the item may already have been handed to someone else.
+func (q *Queue[T]) Ack(receipt Receipt) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	_, err := q.take(receipt)
+	return err
+}
+
+// Nack makes the item behind receipt receivable again straight away.
+func (q *Queue[T]) Nack(receipt Receipt) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	f, err := q.take(receipt)
+	if err != nil {
+		return err
+	}
+
+	q.release(f.envelope)
+	return nil
+}
+
+// DeadLetter dequeues an item that exceeded the maximum number of receives.
+func (q *Queue[T]) DeadLetter() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	return q.deadLetters.TryDequeue()
+}
+
+// Len counts the items that can be received right now.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	return q.ready.Len()
+}
+
+// InFlight counts the items that were received but not yet acknowledged.
+func (q *Queue[T]) InFlight() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	return q.inFlight.Len()
+}
+
+func (q *Queue[T]) DeadLetterLen() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(q.config.clock.Now())
+
+	return q.deadLetters.Len()
+}
+
+// expire releases every in-flight item whose visibility timeout has run out.
+// Timeouts are only checked when the queue is used, so an idle queue needs no
+// timers.
+func (q *Queue[T]) expire(now time.Time) {
+	for {
+		f, ok := q.inFlight.Peek()
+		if !ok || f.deadline.After(now) {
+			return
+		}
+
+		q.inFlight.Pop()
+		delete(q.receipts, f.receipt)
+		q.release(f.envelope)
+	}
+}
+
+func (q *Queue[T]) take(receipt Receipt) (inFlight[T], error) {
+	h, ok := q.receipts[receipt]
+	if !ok || receipt == (Receipt{}) {
+		return inFlight[T]{}, ErrInvalidReceipt
+	}
+
+	delete(q.receipts, receipt)
+	return q.inFlight.Remove(h)
+}
+
+func (q *Queue[T]) release(e *envelope[T]) {
+	if q.config.maxReceives > 0 && e.receives >= q.config.maxReceives {
+		q.deadLetters.Enqueue(e.item)
+		return
+	}
+
+	q.ready.Enqueue(e)
+}
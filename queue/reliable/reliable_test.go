@@ -0,0 +1,195 @@
+package reliable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []string{"first", "second", "third"}
+
+type testContext struct {
+	clock *clock.Fake
+	queue *Queue[string]
+}
+
+func (c *testContext) beforeEach(opts ...Option) {
+	c.clock = clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.queue = New[string](append([]Option{WithClock(c.clock), WithVisibilityTimeout(time.Minute)}, opts...)...)
+
+	for _, item := range items {
+		c.queue.Enqueue(item)
+	}
+}
+
+func testCase(test func(*testing.T, *testContext), opts ...Option) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach(opts...)
+		test(t, context)
+	}
+}
+
+func TestReceive(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, tc *testContext) {
+		for _, want := range items {
+			m, ok := tc.queue.Receive()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, m.Item, want)
+			utils.ValidateResult(t, m.Receives, 1)
+		}
+
+		_, ok := tc.queue.Receive()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Hides received items", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Receive()
+
+		utils.ValidateResult(t, tc.queue.Len(), len(items)-1)
+		utils.ValidateResult(t, tc.queue.InFlight(), 1)
+	}))
+
+	t.Run("Hands out a new receipt for every delivery", testCase(func(t *testing.T, tc *testContext) {
+		first, _ := tc.queue.Receive()
+		tc.queue.Nack(first.Receipt)
+
+		for i := 1; i < len(items); i++ {
+			tc.queue.Receive()
+		}
+
+		again, _ := tc.queue.Receive()
+		utils.ValidateResult(t, again.Item, first.Item)
+
+		if again.Receipt == first.Receipt {
+			t.Error("Expected a redelivery to get a new receipt but it reused the old one")
+		}
+	}))
+}
+
+func TestAck(t *testing.T) {
+	t.Run("Deletes the item", testCase(func(t *testing.T, tc *testContext) {
+		m, _ := tc.queue.Receive()
+
+		utils.ValidateResult(t, tc.queue.Ack(m.Receipt), nil)
+		utils.ValidateResult(t, tc.queue.InFlight(), 0)
+
+		tc.clock.Advance(time.Hour)
+		utils.ValidateResult(t, tc.queue.Len(), len(items)-1)
+	}))
+
+	t.Run("Rejects a receipt used twice", testCase(func(t *testing.T, tc *testContext) {
+		m, _ := tc.queue.Receive()
+		tc.queue.Ack(m.Receipt)
+
+		utils.ValidateResult(t, tc.queue.Ack(m.Receipt), ErrInvalidReceipt)
+	}))
+
+	t.Run("Rejects a receipt whose timeout expired", testCase(func(t *testing.T, tc *testContext) {
+		m, _ := tc.queue.Receive()
+		tc.clock.Advance(time.Minute)
+
+		utils.ValidateResult(t, tc.queue.Ack(m.Receipt), ErrInvalidReceipt)
+		utils.ValidateResult(t, tc.queue.Len(), len(items))
+	}))
+}
+
+func TestNack(t *testing.T) {
+	t.Run("Returns the item to the back of the queue", testCase(func(t *testing.T, tc *testContext) {
+		m, _ := tc.queue.Receive()
+		utils.ValidateResult(t, tc.queue.Nack(m.Receipt), nil)
+
+		var got []string
+		for {
+			m, ok := tc.queue.Receive()
+			if !ok {
+				break
+			}
+			got = append(got, m.Item)
+		}
+
+		want := []string{"second", "third", "first"}
+		for i := range want {
+			utils.ValidateResult(t, got[i], want[i])
+		}
+	}))
+
+	t.Run("Rejects the zero receipt", testCase(func(t *testing.T, tc *testContext) {
+		tc.queue.Receive()
+
+		utils.ValidateResult(t, tc.queue.Ack(Receipt{}), ErrInvalidReceipt)
+		utils.ValidateResult(t, tc.queue.Nack(Receipt{}), ErrInvalidReceipt)
+		utils.ValidateResult(t, tc.queue.InFlight(), 1)
+	}))
+
+	t.Run("Rejects an unknown receipt", testCase(func(t *testing.T, tc *testContext) {
+		utils.ValidateResult(t, tc.queue.Nack(Receipt{42}), ErrInvalidReceipt)
+	}))
+}
+
+func TestVisibilityTimeout(t *testing.T) {
+	t.Run("Redelivers an item once its timeout expires", testCase(func(t *testing.T, tc *testContext) {
+		first, _ := tc.queue.Receive()
+		tc.queue.Receive()
+		tc.queue.Receive()
+
+		tc.clock.Advance(59 * time.Second)
+		_, ok := tc.queue.Receive()
+		utils.ValidateResult(t, ok, false)
+
+		tc.clock.Advance(time.Second)
+		m, ok := tc.queue.Receive()
+		utils.ValidateResult(t, ok, true)
+		utils.ValidateResult(t, m.Item, first.Item)
+		utils.ValidateResult(t, m.Receives, 2)
+	}))
+
+	t.Run("Redelivers expired items in the order they were received", testCase(func(t *testing.T, tc *testContext) {
+		for range items {
+			tc.queue.Receive()
+			tc.clock.Advance(time.Second)
+		}
+
+		tc.clock.Advance(time.Hour)
+
+		for _, want := range items {
+			m, _ := tc.queue.Receive()
+			utils.ValidateResult(t, m.Item, want)
+		}
+	}))
+}
+
+func TestMaxReceives(t *testing.T) {
+	t.Run("Moves an item to the dead-letter queue", testCase(func(t *testing.T, tc *testContext) {
+		for i := 0; i < 2; i++ {
+			for range items {
+				m, _ := tc.queue.Receive()
+				if m.Item == "second" {
+					tc.queue.Nack(m.Receipt)
+				} else {
+					tc.queue.Ack(m.Receipt)
+				}
+			}
+		}
+
+		utils.ValidateResult(t, tc.queue.Len(), 0)
+		utils.ValidateResult(t, tc.queue.DeadLetterLen(), 1)
+
+		got, _ := tc.queue.DeadLetter()
+		utils.ValidateResult(t, got, "second")
+	}, WithMaxReceives(2)))
+
+	t.Run("Counts expired timeouts as receives", testCase(func(t *testing.T, tc *testContext) {
+		for i := 0; i < 2; i++ {
+			for range items {
+				tc.queue.Receive()
+			}
+			tc.clock.Advance(time.Minute)
+		}
+
+		utils.ValidateResult(t, tc.queue.DeadLetterLen(), len(items))
+		utils.ValidateResult(t, tc.queue.InFlight(), 0)
+	}, WithMaxReceives(2)))
+}
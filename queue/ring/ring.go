@@ -0,0 +1,170 @@
+// Package ring implements a FIFO queue on a circular slice whose length is
+// always a power of two, so positions wrap with a mask instead of a modulo.
+package ring
+
+import (
+	"fmt"
+	"sync"
+)
+
+const minCapacity = 8
+
+type config struct {
+	capacity int
+	shrink   bool
+}
+
+type Option func(*config)
+
+// WithCapacity sets the initial capacity, rounded up to a power of two. The
+// queue never shrinks below it.
+func WithCapacity(n int) Option {
+	return func(c *config) { c.capacity = n }
+}
+
+// WithShrinking halves the backing slice whenever fewer than a quarter of its
+// slots are in use.
+func WithShrinking() Option {
+	return func(c *config) { c.shrink = true }
+}
+
+type Queue[T any] struct {
+	buf    []T
+	head   int
+	len    int
+	minCap int
+	shrink bool
+	mu     sync.Mutex
+}
+
+func New[T any](opts ...Option) *Queue[T] {
+	c := config{capacity: minCapacity}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	capacity := nextPowerOfTwo(max(c.capacity, 1))
+
+	return &Queue[T]{buf: make([]T, capacity), minCap: capacity, shrink: c.shrink}
+}
+
+func (q *Queue[T]) Enqueue(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.len == len(q.buf) {
+		q.resize(len(q.buf) * 2)
+	}
+
+	q.buf[(q.head+q.len)&q.mask()] = item
+	q.len++
+}
+
+func (q *Queue[T]) Dequeue() T {
+	item, _ := q.TryDequeue()
+	return item
+}
+
+func (q *Queue[T]) TryDequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+
+	if q.len == 0 {
+		return zero, false
+	}
+
+	item := q.buf[q.head]
+	q.buf[q.head] = zero
+	q.head = (q.head + 1) & q.mask()
+	q.len--
+
+	if q.shrink && len(q.buf) > q.minCap && q.len < len(q.buf)/4 {
+		q.resize(len(q.buf) / 2)
+	}
+
+	return item, true
+}
+
+func (q *Queue[T]) Peek() T {
+	item, _ := q.TryPeek()
+	return item
+}
+
+func (q *Queue[T]) TryPeek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.len == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.buf[q.head], true
+}
+
+// At returns the item at index counted from the front, which is index 0.
+func (q *Queue[T]) At(index int) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if index < 0 || index >= q.len {
+		var zero T
+		return zero, fmt.Errorf("Index out of bounds: index %d provided but queue has length %d", index, q.len)
+	}
+
+	return q.buf[(q.head+index)&q.mask()], nil
+}
+
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.len
+}
+
+func (q *Queue[T]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.buf)
+}
+
+func (q *Queue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.buf = make([]T, q.minCap)
+	q.head = 0
+	q.len = 0
+}
+
+func (q *Queue[T]) mask() int { return len(q.buf) - 1 }
+
+// resize moves the items into a slice of the given power-of-two capacity,
+// unwrapping them so that the front lands at index 0.
+func (q *Queue[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+
+	if q.head+q.len <= len(q.buf) {
+		copy(buf, q.buf[q.head:q.head+q.len])
+	} else {
+		n := copy(buf, q.buf[q.head:])
+		copy(buf[n:], q.buf[:q.len-n])
+	}
+
+	q.buf = buf
+	q.head = 0
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+
+	return p
+}
@@ -0,0 +1,240 @@
+package ring
+
+import (
+	"math/rand"
+	"testing"
+
+	linkedqueue "github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []interface{}{1, "string", 0.4, "another string"}
+
+type testContext struct {
+	queue *Queue[interface{}]
+}
+
+func (c *testContext) beforeEach() {
+	q := New[interface{}](WithCapacity(2))
+
+	for _, item := range items {
+		q.Enqueue(item)
+	}
+
+	c.queue = q
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Rounds the capacity up to a power of two", func(t *testing.T) {
+		got := New[int](WithCapacity(5)).Cap()
+		want := 8
+		utils.ValidateResult(t, got, want)
+	})
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Run("Doubles the capacity when full", testCase(func(t *testing.T, c *testContext) {
+		got := c.queue.Cap()
+		want := 4
+		utils.ValidateResult(t, got, want)
+
+		c.queue.Enqueue("overflow")
+		got = c.queue.Cap()
+		want = 8
+		utils.ValidateResult(t, got, want)
+	}))
+
+	t.Run("Keeps FIFO order when growing a wrapped buffer", func(t *testing.T) {
+		q := New[int](WithCapacity(4))
+		for i := 0; i < 4; i++ {
+			q.Enqueue(i)
+		}
+		q.Dequeue()
+		q.Dequeue()
+		for i := 4; i < 10; i++ {
+			q.Enqueue(i)
+		}
+
+		for want := 2; want < 10; want++ {
+			utils.ValidateResult(t, q.Dequeue(), want)
+		}
+	})
+}
+
+func TestTryDequeue(t *testing.T) {
+	t.Run("Returns items in FIFO order", testCase(func(t *testing.T, c *testContext) {
+		for i := range items {
+			got, ok := c.queue.TryDequeue()
+			utils.ValidateResult(t, ok, true)
+			utils.ValidateResult(t, got, items[i])
+		}
+
+		_, ok := c.queue.TryDequeue()
+		utils.ValidateResult(t, ok, false)
+	}))
+
+	t.Run("Clears the vacated slot", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Dequeue()
+		got := c.queue.buf[0]
+		var want interface{} = nil
+		utils.ValidateResult(t, got, want)
+	}))
+
+	t.Run("Shrinks below a quarter of the capacity when enabled", func(t *testing.T) {
+		q := New[int](WithShrinking())
+		for i := 0; i < 64; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < 60; i++ {
+			q.Dequeue()
+		}
+
+		if q.Cap() > 16 {
+			t.Errorf("Expected capacity of at most 16 but got %d", q.Cap())
+		}
+
+		for want := 60; want < 64; want++ {
+			utils.ValidateResult(t, q.Dequeue(), want)
+		}
+	})
+
+	t.Run("Never shrinks below the initial capacity", func(t *testing.T) {
+		q := New[int](WithCapacity(16), WithShrinking())
+		for i := 0; i < 64; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < 64; i++ {
+			q.Dequeue()
+		}
+
+		utils.ValidateResult(t, q.Cap(), 16)
+	})
+
+	t.Run("Does not shrink by default", func(t *testing.T) {
+		q := New[int]()
+		for i := 0; i < 64; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < 64; i++ {
+			q.Dequeue()
+		}
+
+		utils.ValidateResult(t, q.Cap(), 64)
+	})
+}
+
+func TestAt(t *testing.T) {
+	t.Run("Indexes from the front", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Dequeue()
+		c.queue.Enqueue("wrapped")
+
+		want := []interface{}{items[1], items[2], items[3], "wrapped"}
+
+		for i := range want {
+			got, err := c.queue.At(i)
+			if err != nil {
+				t.Fatalf("Could not get item: %s", err.Error())
+			}
+			utils.ValidateResult(t, got, want[i])
+		}
+	}))
+
+	t.Run("Throws error when out of bounds", testCase(func(t *testing.T, c *testContext) {
+		if _, err := c.queue.At(-1); err == nil {
+			t.Error("Expected negative bounds to throw error but it didn't")
+		}
+
+		if _, err := c.queue.At(len(items)); err == nil {
+			t.Error("Expected index exceeding upper bound to throw error but it didn't")
+		}
+	}))
+}
+
+func TestPeekLenAndClear(t *testing.T) {
+	t.Run("Peek returns the front without removing it", testCase(func(t *testing.T, c *testContext) {
+		utils.ValidateResult(t, c.queue.Peek(), items[0])
+		utils.ValidateResult(t, c.queue.Len(), len(items))
+	}))
+
+	t.Run("Clear empties the queue", testCase(func(t *testing.T, c *testContext) {
+		c.queue.Clear()
+		utils.ValidateResult(t, c.queue.IsEmpty(), true)
+
+		_, ok := c.queue.TryPeek()
+		utils.ValidateResult(t, ok, false)
+	}))
+}
+
+func TestMatchesSliceModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := New[int](WithCapacity(1), WithShrinking())
+	var model []int
+
+	for i := 0; i < 10000; i++ {
+		if rng.Intn(3) > 0 {
+			q.Enqueue(i)
+			model = append(model, i)
+		} else {
+			got, ok := q.TryDequeue()
+			utils.ValidateResult(t, ok, len(model) > 0)
+			if len(model) > 0 {
+				utils.ValidateResult(t, got, model[0])
+				model = model[1:]
+			}
+		}
+
+		utils.ValidateResult(t, q.Len(), len(model))
+		if len(model) > 0 {
+			last, _ := q.At(len(model) - 1)
+			utils.ValidateResult(t, last, model[len(model)-1])
+		}
+	}
+}
+
+func BenchmarkEnqueueDequeue(b *testing.B) {
+	const batch = 64
+
+	b.Run("ring", func(b *testing.B) {
+		q := New[int]()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < batch; j++ {
+				q.Enqueue(j)
+			}
+			for j := 0; j < batch; j++ {
+				q.Dequeue()
+			}
+		}
+	})
+
+	b.Run("linked", func(b *testing.B) {
+		q := linkedqueue.NewOf[int]()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < batch; j++ {
+				q.Enqueue(j)
+			}
+			for j := 0; j < batch; j++ {
+				q.Dequeue()
+			}
+		}
+	})
+
+	b.Run("channel", func(b *testing.B) {
+		ch := make(chan int, batch)
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < batch; j++ {
+				ch <- j
+			}
+			for j := 0; j < batch; j++ {
+				<-ch
+			}
+		}
+	})
+}
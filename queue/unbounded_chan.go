@@ -0,0 +1,58 @@
+package queue
+
+import "sync/atomic"
+
+// UnboundedChan connects In to Out through a linked queue so that sends on In
+// never wait for the consumer. Closing In lets the remaining items drain to
+// Out, after which Out is closed and the buffering goroutine exits. Out must
+// be read until it is closed, otherwise that goroutine is leaked.
+type UnboundedChan[T any] struct {
+	In       chan<- T
+	Out      <-chan T
+	buffered atomic.Int64
+}
+
+func NewUnboundedChan[T any]() *UnboundedChan[T] {
+	in := make(chan T)
+	out := make(chan T)
+	c := &UnboundedChan[T]{In: in, Out: out}
+
+	go c.run(in, out)
+
+	return c
+}
+
+// Len reports how many items are buffered between In and Out.
+func (c *UnboundedChan[T]) Len() int {
+	return int(c.buffered.Load())
+}
+
+func (c *UnboundedChan[T]) run(in <-chan T, out chan<- T) {
+	defer close(out)
+
+	var buffer Queue[T]
+
+	for in != nil || buffer.len > 0 {
+		var send chan<- T
+		var next T
+
+		if buffer.first != nil {
+			send = out
+			next = buffer.first.item
+		}
+
+		select {
+		case item, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+
+			buffer.enqueue(item)
+			c.buffered.Add(1)
+		case send <- next:
+			buffer.dequeue()
+			c.buffered.Add(-1)
+		}
+	}
+}
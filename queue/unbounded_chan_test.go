@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestUnboundedChan(t *testing.T) {
+	t.Run("Sends never block without a consumer", func(t *testing.T) {
+		c := NewUnboundedChan[int]()
+		const n = 10000
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < n; i++ {
+				c.In <- i
+			}
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected sends to complete without a consumer but they blocked")
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for c.Len() < n && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		utils.ValidateResult(t, c.Len(), n)
+
+		close(c.In)
+		for range c.Out {
+		}
+	})
+
+	t.Run("Preserves FIFO order under heavy load", func(t *testing.T) {
+		c := NewUnboundedChan[int]()
+		const n = 100000
+
+		go func() {
+			for i := 0; i < n; i++ {
+				c.In <- i
+			}
+			close(c.In)
+		}()
+
+		want := 0
+		for got := range c.Out {
+			if got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			want++
+		}
+
+		utils.ValidateResult(t, want, n)
+	})
+
+	t.Run("Preserves per-producer order with concurrent producers", func(t *testing.T) {
+		c := NewUnboundedChan[[2]int]()
+		const producers, perProducer = 8, 10000
+
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					c.In <- [2]int{p, i}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(c.In)
+		}()
+
+		next := make([]int, producers)
+		for item := range c.Out {
+			p, i := item[0], item[1]
+			if i != next[p] {
+				t.Fatalf("Producer %d: got item %d, want %d", p, i, next[p])
+			}
+			next[p]++
+		}
+
+		for _, count := range next {
+			utils.ValidateResult(t, count, perProducer)
+		}
+	})
+
+	t.Run("Drains buffered items after In is closed", func(t *testing.T) {
+		c := NewUnboundedChan[string]()
+		c.In <- "foo"
+		c.In <- "bar"
+		close(c.In)
+
+		utils.ValidateResult(t, <-c.Out, "foo")
+		utils.ValidateResult(t, <-c.Out, "bar")
+
+		_, ok := <-c.Out
+		utils.ValidateResult(t, ok, false)
+		utils.ValidateResult(t, c.Len(), 0)
+	})
+
+	t.Run("Does not leak goroutines", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+
+		for i := 0; i < 100; i++ {
+			c := NewUnboundedChan[int]()
+			c.In <- i
+			close(c.In)
+			for range c.Out {
+			}
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("Expected at most %d goroutines but got %d", before, after)
+		}
+	})
+}
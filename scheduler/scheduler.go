@@ -0,0 +1,205 @@
+// Package scheduler runs fork-join tasks on a fixed pool of workers. Each
+// worker keeps its own work-stealing deque: tasks spawned by a task go to the
+// bottom of its worker's deque, and idle workers steal from the top of the
+// others'. Tasks spawned from outside the pool go through a shared queue.
+package scheduler
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/deque/worksteal"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+)
+
+type Task struct {
+	fn   func(*Worker)
+	done chan struct{}
+}
+
+// Wait blocks until the task has run. Tasks must use Worker.Wait instead, so
+// that their worker keeps running other tasks in the meantime.
+func (t *Task) Wait() { <-t.done }
+
+func (t *Task) isDone() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Worker is handed to every task and is only valid on the goroutine running
+// that task.
+type Worker struct {
+	scheduler *Scheduler
+	tasks     *worksteal.Deque[*Task]
+	rng       *rand.Rand
+}
+
+type Scheduler struct {
+	workers  []*Worker
+	injected *queue.Queue[*Task]
+	pending  sync.WaitGroup
+	running  sync.WaitGroup
+	version  atomic.Uint64
+	sleeping atomic.Int32
+	closed   bool
+	wake     *sync.Cond
+	mu       sync.Mutex
+}
+
+// New starts a scheduler with the given number of workers, or one per CPU if
+// workers is zero or less.
+func New(workers int) *Scheduler {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	s := &Scheduler{injected: queue.NewOf[*Task]()}
+	s.wake = sync.NewCond(&s.mu)
+
+	for i := 0; i < workers; i++ {
+		s.workers = append(s.workers, &Worker{
+			scheduler: s,
+			tasks:     worksteal.New[*Task](),
+			rng:       rand.New(rand.NewSource(int64(i))),
+		})
+	}
+
+	for _, w := range s.workers {
+		s.running.Add(1)
+		go w.loop()
+	}
+
+	return s
+}
+
+// Spawn schedules fn from outside the pool.
+func (s *Scheduler) Spawn(fn func(*Worker)) *Task {
+	t := s.newTask(fn)
+	s.injected.Enqueue(t)
+	s.notify()
+
+	return t
+}
+
+// Wait blocks until every spawned task, including those spawned by tasks, has
+// run.
+func (s *Scheduler) Wait() {
+	s.pending.Wait()
+}
+
+// Close stops the workers once they run out of tasks and waits for them to
+// exit. Spawning after Close is not allowed.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.wake.Broadcast()
+	s.mu.Unlock()
+
+	s.running.Wait()
+}
+
+// Spawn schedules fn on this worker's deque, from where other workers may
+// steal it.
+func (w *Worker) Spawn(fn func(*Worker)) *Task {
+	t := w.scheduler.newTask(fn)
+	w.tasks.Push(t)
+	w.scheduler.notify()
+
+	return t
+}
+
+// Wait runs other tasks until t has run.
+func (w *Worker) Wait(t *Task) {
+	for !t.isDone() {
+		if next, ok := w.find(); ok {
+			w.run(next)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+func (w *Worker) loop() {
+	s := w.scheduler
+	defer s.running.Done()
+
+	for {
+		version := s.version.Load()
+
+		if t, ok := w.find(); ok {
+			w.run(t)
+			continue
+		}
+
+		s.mu.Lock()
+
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+
+		s.sleeping.Add(1)
+		if s.version.Load() == version {
+			s.wake.Wait()
+		}
+		s.sleeping.Add(-1)
+
+		s.mu.Unlock()
+	}
+}
+
+func (w *Worker) find() (*Task, bool) {
+	if t, ok := w.tasks.Pop(); ok {
+		return t, true
+	}
+
+	if t, ok := w.scheduler.injected.TryDequeue(); ok {
+		return t, true
+	}
+
+	workers := w.scheduler.workers
+	start := w.rng.Intn(len(workers))
+
+	for i := range workers {
+		victim := workers[(start+i)%len(workers)]
+		if victim == w {
+			continue
+		}
+
+		if t, ok := victim.tasks.Steal(); ok {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+func (w *Worker) run(t *Task) {
+	t.fn(w)
+	close(t.done)
+	w.scheduler.pending.Done()
+}
+
+func (s *Scheduler) newTask(fn func(*Worker)) *Task {
+	s.pending.Add(1)
+	return &Task{fn: fn, done: make(chan struct{})}
+}
+
+// notify wakes sleeping workers. A worker only goes to sleep if no task was
+// spawned since it last looked for one, so bumping the version before
+// checking for sleepers means no task is left without a worker to notice it.
+func (s *Scheduler) notify() {
+	s.version.Add(1)
+
+	if s.sleeping.Load() > 0 {
+		s.mu.Lock()
+		s.wake.Broadcast()
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func fib(w *Worker, n int) int {
+	if n < 2 {
+		return n
+	}
+
+	var a int
+	t := w.Spawn(func(w *Worker) { a = fib(w, n-1) })
+	b := fib(w, n-2)
+	w.Wait(t)
+
+	return a + b
+}
+
+func mergeSort(w *Worker, s []int) {
+	if len(s) <= 32 {
+		slices.Sort(s)
+		return
+	}
+
+	mid := len(s) / 2
+	t := w.Spawn(func(w *Worker) { mergeSort(w, s[:mid]) })
+	mergeSort(w, s[mid:])
+	w.Wait(t)
+
+	merged := make([]int, 0, len(s))
+	i, j := 0, mid
+	for i < mid && j < len(s) {
+		if s[i] <= s[j] {
+			merged = append(merged, s[i])
+			i++
+		} else {
+			merged = append(merged, s[j])
+			j++
+		}
+	}
+	merged = append(merged, s[i:mid]...)
+	merged = append(merged, s[j:]...)
+	copy(s, merged)
+}
+
+func TestFibonacci(t *testing.T) {
+	s := New(4)
+	defer s.Close()
+
+	var got int
+	task := s.Spawn(func(w *Worker) { got = fib(w, 25) })
+	task.Wait()
+
+	utils.ValidateResult(t, got, 75025)
+}
+
+func TestMergeSort(t *testing.T) {
+	s := New(0)
+	defer s.Close()
+
+	rng := rand.New(rand.NewSource(1))
+	items := make([]int, 100000)
+	for i := range items {
+		items[i] = rng.Int()
+	}
+
+	want := slices.Clone(items)
+	slices.Sort(want)
+
+	s.Spawn(func(w *Worker) { mergeSort(w, items) })
+	s.Wait()
+
+	utils.ValidateResult(t, slices.Equal(items, want), true)
+}
+
+func TestWait(t *testing.T) {
+	t.Run("Waits for tasks spawned by tasks", func(t *testing.T) {
+		s := New(4)
+		defer s.Close()
+
+		var count atomic.Int32
+		for i := 0; i < 10; i++ {
+			s.Spawn(func(w *Worker) {
+				for j := 0; j < 10; j++ {
+					w.Spawn(func(*Worker) { count.Add(1) })
+				}
+			})
+		}
+
+		s.Wait()
+		utils.ValidateResult(t, count.Load(), int32(100))
+	})
+
+	t.Run("Spreads work across workers", func(t *testing.T) {
+		s := New(4)
+		defer s.Close()
+
+		var running, peak atomic.Int32
+		s.Spawn(func(w *Worker) {
+			for i := 0; i < 4; i++ {
+				w.Spawn(func(*Worker) {
+					now := running.Add(1)
+					for {
+						old := peak.Load()
+						if now <= old || peak.CompareAndSwap(old, now) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					running.Add(-1)
+				})
+			}
+		})
+		s.Wait()
+
+		if peak.Load() < 2 {
+			t.Errorf("Expected tasks to be stolen by idle workers but at most %d ran at once", peak.Load())
+		}
+	})
+}
+
+func TestClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	s := New(8)
+	s.Spawn(func(*Worker) {})
+	s.Wait()
+	s.Close()
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected at most %d goroutines after closing but got %d", before, after)
+	}
+}
@@ -0,0 +1,279 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrFull = errors.New("stack is full")
+
+// Overflow decides what Push does when a bounded stack is full.
+type Overflow int
+
+const (
+	// Reject makes Push fail with ErrFull.
+	Reject Overflow = iota
+	// DropOldest evicts the bottom item to make room.
+	DropOldest
+	// Block makes Push wait until an item is popped.
+	Block
+)
+
+// boundedNode links down towards the bottom through next and up towards the
+// top through up, so that DropOldest can remove the bottom in O(1).
+type boundedNode[T any] struct {
+	item T
+	next *boundedNode[T]
+	up   *boundedNode[T]
+}
+
+// Bounded is a stack that holds at most a fixed number of items.
+type Bounded[T any] struct {
+	sp       *boundedNode[T]
+	bottom   *boundedNode[T]
+	len      int
+	capacity int
+	overflow Overflow
+	onEvict  func(T)
+	changed  chan struct{}
+	mu       sync.Mutex
+}
+
+type boundedOptions[T any] struct {
+	capacity int
+	overflow Overflow
+	onEvict  func(T)
+}
+
+// BoundedOption configures a Bounded stack. Its type parameter is the item
+// type, so that WithOnEvict is checked against the stack it is given to.
+type BoundedOption[T any] func(*boundedOptions[T])
+
+// WithCapacity bounds the stack to n items. It is required, and n must be at
+// least 1.
+func WithCapacity[T any](n int) BoundedOption[T] {
+	return func(opts *boundedOptions[T]) { opts.capacity = n }
+}
+
+// WithOverflow sets what Push does when the stack is at capacity. The default
+// is Reject.
+func WithOverflow[T any](policy Overflow) BoundedOption[T] {
+	return func(opts *boundedOptions[T]) { opts.overflow = policy }
+}
+
+// WithOnEvict calls fn with every item that DropOldest evicts.
+func WithOnEvict[T any](fn func(T)) BoundedOption[T] {
+	return func(opts *boundedOptions[T]) { opts.onEvict = fn }
+}
+
+// NewBounded creates a stack of at most the items set by WithCapacity. It
+// panics if the capacity is missing or below 1.
+func NewBounded[T any](opts ...BoundedOption[T]) *Bounded[T] {
+	var o boundedOptions[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.capacity < 1 {
+		panic(fmt.Sprintf("stack: bounded stack needs a capacity of at least 1, got %d", o.capacity))
+	}
+
+	s := &Bounded[T]{capacity: o.capacity, overflow: o.overflow, onEvict: o.onEvict}
+
+	if s.overflow == Block {
+		s.changed = make(chan struct{})
+	}
+
+	return s
+}
+
+// Push adds item to the top. On a full stack it follows the overflow policy,
+// returning ErrFull under Reject; under Block it waits indefinitely, like
+// PushContext with a context that is never done.
+func (s *Bounded[T]) Push(item T) error {
+	return s.PushContext(context.Background(), item)
+}
+
+// PushContext is Push, except that under the Block policy it gives up and
+// returns the context's error once ctx is done.
+func (s *Bounded[T]) PushContext(ctx context.Context, item T) error {
+	for {
+		s.mu.Lock()
+
+		if !s.isFull() {
+			s.push(item)
+			s.mu.Unlock()
+			return nil
+		}
+
+		switch s.overflow {
+		case DropOldest:
+			evicted := s.removeBottom()
+			s.push(item)
+			s.mu.Unlock()
+
+			if s.onEvict != nil {
+				s.onEvict(evicted)
+			}
+			return nil
+		case Block:
+			changed := s.changed
+			s.mu.Unlock()
+
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		default:
+			s.mu.Unlock()
+			return ErrFull
+		}
+	}
+}
+
+// Pop removes and returns the top item. It returns ErrEmpty if the stack is
+// empty.
+func (s *Bounded[T]) Pop() (T, error) {
+	item, ok := s.TryPop()
+	if !ok {
+		return item, ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *Bounded[T]) TryPop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, false
+	}
+
+	item := s.pop()
+	s.freed()
+	return item, true
+}
+
+// DrainAll removes every item, top first.
+func (s *Bounded[T]) DrainAll() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]T, 0, s.len)
+	for s.sp != nil {
+		items = append(items, s.pop())
+	}
+
+	if len(items) > 0 {
+		s.freed()
+	}
+
+	return items
+}
+
+// Peek returns the top item without removing it. It returns ErrEmpty if the
+// stack is empty.
+func (s *Bounded[T]) Peek() (T, error) {
+	item, ok := s.TryPeek()
+	if !ok {
+		return item, ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *Bounded[T]) TryPeek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, false
+	}
+
+	return s.sp.item, true
+}
+
+func (s *Bounded[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.len
+}
+
+func (s *Bounded[T]) Cap() int { return s.capacity }
+
+func (s *Bounded[T]) IsEmpty() bool { return s.Len() == 0 }
+
+func (s *Bounded[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sp = nil
+	s.bottom = nil
+	s.len = 0
+	s.freed()
+}
+
+func (s *Bounded[T]) isFull() bool {
+	return s.len >= s.capacity
+}
+
+func (s *Bounded[T]) push(item T) {
+	added := &boundedNode[T]{item: item, next: s.sp}
+
+	if s.sp != nil {
+		s.sp.up = added
+	} else {
+		s.bottom = added
+	}
+
+	s.sp = added
+	s.len++
+}
+
+func (s *Bounded[T]) pop() T {
+	removed := s.sp
+	s.sp = removed.next
+	removed.next = nil
+
+	if s.sp != nil {
+		s.sp.up = nil
+	} else {
+		s.bottom = nil
+	}
+
+	s.len--
+	return removed.item
+}
+
+func (s *Bounded[T]) removeBottom() T {
+	removed := s.bottom
+	s.bottom = removed.up
+	removed.up = nil
+
+	if s.bottom != nil {
+		s.bottom.next = nil
+	} else {
+		s.sp = nil
+	}
+
+	s.len--
+	return removed.item
+}
+
+// freed wakes the pushers waiting for room under the Block policy and hands
+// out a fresh channel to later ones.
+func (s *Bounded[T]) freed() {
+	if s.changed == nil {
+		return
+	}
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,113 @@
+package stack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func fillTo(s *Bounded[int], n int) {
+	for i := 1; i <= n; i++ {
+		s.Push(i)
+	}
+}
+
+func TestReject(t *testing.T) {
+	s := NewBounded(WithCapacity[int](2))
+	fillTo(s, 2)
+
+	utils.ValidateResult(t, s.Push(3), ErrFull)
+	utils.ValidateResult(t, s.Len(), 2)
+
+	s.Pop()
+	utils.ValidateResult(t, s.Push(3), nil)
+}
+
+func TestDropOldest(t *testing.T) {
+	t.Run("Evicts the bottom items in order", func(t *testing.T) {
+		var evicted []int
+		s := NewBounded(WithCapacity[int](3), WithOverflow[int](DropOldest), WithOnEvict(func(item int) {
+			evicted = append(evicted, item)
+		}))
+		fillTo(s, 5)
+
+		utils.ValidateResult(t, s.Len(), 3)
+		utils.ValidateResult(t, len(evicted), 2)
+		utils.ValidateResult(t, evicted[0], 1)
+		utils.ValidateResult(t, evicted[1], 2)
+
+		got := s.DrainAll()
+		for i, want := range []int{5, 4, 3} {
+			utils.ValidateResult(t, got[i], want)
+		}
+	})
+
+	t.Run("Works with a capacity of one", func(t *testing.T) {
+		s := NewBounded(WithCapacity[int](1), WithOverflow[int](DropOldest))
+		fillTo(s, 3)
+
+		got, _ := s.Pop()
+		utils.ValidateResult(t, got, 3)
+		utils.ValidateResult(t, s.IsEmpty(), true)
+
+		s.Push(4)
+		got, _ = s.Pop()
+		utils.ValidateResult(t, got, 4)
+	})
+}
+
+func TestBlock(t *testing.T) {
+	t.Run("Waits until an item is popped", func(t *testing.T) {
+		s := NewBounded(WithCapacity[int](1), WithOverflow[int](Block))
+		fillTo(s, 1)
+
+		done := make(chan error)
+		go func() { done <- s.Push(2) }()
+
+		select {
+		case <-done:
+			t.Fatal("Expected Push to wait for room but it returned")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		s.Pop()
+		utils.ValidateResult(t, <-done, nil)
+
+		got, _ := s.Peek()
+		utils.ValidateResult(t, got, 2)
+	})
+
+	t.Run("Gives up when the context is done", func(t *testing.T) {
+		s := NewBounded(WithCapacity[int](1), WithOverflow[int](Block))
+		fillTo(s, 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		utils.ValidateResult(t, s.PushContext(ctx, 2), context.DeadlineExceeded)
+		utils.ValidateResult(t, s.Len(), 1)
+	})
+}
+
+func TestNewBounded(t *testing.T) {
+	t.Run("Reports its capacity", func(t *testing.T) {
+		utils.ValidateResult(t, NewBounded(WithCapacity[int](3)).Cap(), 3)
+	})
+
+	for name, opts := range map[string][]BoundedOption[int]{
+		"Panics without a capacity":    nil,
+		"Panics on a capacity below 1": {WithCapacity[int](0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected NewBounded to panic but it did not")
+				}
+			}()
+
+			NewBounded(opts...)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package lockfree implements Treiber's non-blocking LIFO stack.
+//
+// The stack is a singly linked list whose top is swapped with compare-and-swap:
+// Push links a new node to the current top and swings the top to it, and Pop
+// swings the top to its successor. A CAS that loses a race just retries
+// against the new top.
+//
+// In languages with manual memory management this scheme suffers from the ABA
+// problem: a popped node can be freed and its address reused for a new node
+// pushed in its place, so a stale CAS that expects the old top still succeeds
+// and installs a successor that is no longer on the stack. Go's garbage
+// collector rules this out. A goroutine that loaded the old top still holds a
+// pointer to it, which keeps the node alive, so no new node can be allocated
+// at that address while any CAS might compare against it. This only holds as
+// long as nodes are never recycled, which is why Push always allocates.
+package lockfree
+
+import (
+	"sync/atomic"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+type node[T any] struct {
+	item T
+	next *node[T]
+}
+
+type Stack[T any] struct {
+	top atomic.Pointer[node[T]]
+	len atomic.Int64
+}
+
+func New[T any]() *Stack[T] {
+	return new(Stack[T])
+}
+
+func (s *Stack[T]) Push(item T) {
+	n := &node[T]{item: item}
+
+	for {
+		n.next = s.top.Load()
+
+		if s.top.CompareAndSwap(n.next, n) {
+			s.len.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top item. It returns stack.ErrEmpty if the
+// stack is empty.
+func (s *Stack[T]) Pop() (T, error) {
+	item, ok := s.TryPop()
+	if !ok {
+		return item, stack.ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *Stack[T]) TryPop() (T, bool) {
+	for {
+		top := s.top.Load()
+
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+
+		// top.next never changes once top is published, so reading it
+		// without synchronization is safe.
+		if s.top.CompareAndSwap(top, top.next) {
+			s.len.Add(-1)
+			return top.item, true
+		}
+	}
+}
+
+// Peek returns the top item without removing it. It returns stack.ErrEmpty if
+// the stack is empty.
+func (s *Stack[T]) Peek() (T, error) {
+	item, ok := s.TryPeek()
+	if !ok {
+		return item, stack.ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *Stack[T]) TryPeek() (T, bool) {
+	if top := s.top.Load(); top != nil {
+		return top.item, true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Len is exact when the stack is quiescent and approximate while operations
+// are in flight.
+func (s *Stack[T]) Len() int {
+	return int(max(s.len.Load(), 0))
+}
+
+func (s *Stack[T]) IsEmpty() bool { return s.top.Load() == nil }
+
+func (s *Stack[T]) Clear() {
+	for {
+		if _, ok := s.TryPop(); !ok {
+			return
+		}
+	}
+}
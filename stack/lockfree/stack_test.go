@@ -0,0 +1,187 @@
+package lockfree
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	mutexstack "github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+var items = []interface{}{1, "string"}
+
+type testContext struct {
+	stack *Stack[interface{}]
+}
+
+func (c *testContext) beforeEach() {
+	s := New[interface{}]()
+
+	for _, item := range items {
+		s.Push(item)
+	}
+
+	c.stack = s
+}
+
+func testCase(test func(*testing.T, *testContext)) func(*testing.T) {
+	return func(t *testing.T) {
+		context := &testContext{}
+		context.beforeEach()
+		test(t, context)
+	}
+}
+
+func TestPush(t *testing.T) {
+	t.Run("Push links items in LIFO order", testCase(func(t *testing.T, c *testContext) {
+		nthStackItem := c.stack.top.Load()
+
+		for i := len(items) - 1; i >= 0; i-- {
+			utils.ValidateResult(t, nthStackItem.item, items[i])
+			nthStackItem = nthStackItem.next
+		}
+
+		if nthStackItem != nil {
+			t.Errorf("Expected nthStackItem to be nil but got %v", nthStackItem)
+		}
+	}))
+}
+
+func TestPop(t *testing.T) {
+	t.Run("Returns items in LIFO order", testCase(func(t *testing.T, c *testContext) {
+		for i := len(items) - 1; i >= 0; i-- {
+			got, err := c.stack.Pop()
+			utils.ValidateResult(t, err, nil)
+			utils.ValidateResult(t, got, items[i])
+		}
+
+		_, err := c.stack.Pop()
+		utils.ValidateResult(t, err, mutexstack.ErrEmpty)
+	}))
+
+	t.Run("Distinguishes a stored nil from an empty stack", func(t *testing.T) {
+		s := New[interface{}]()
+		s.Push(nil)
+
+		_, ok := s.TryPop()
+		utils.ValidateResult(t, ok, true)
+
+		_, ok = s.TryPop()
+		utils.ValidateResult(t, ok, false)
+	})
+}
+
+func TestPeek(t *testing.T) {
+	t.Run("Returns the top item without removing it", testCase(func(t *testing.T, c *testContext) {
+		got, _ := c.stack.Peek()
+		utils.ValidateResult(t, got, items[len(items)-1])
+		utils.ValidateResult(t, c.stack.Len(), len(items))
+	}))
+
+	t.Run("Returns ErrEmpty on an empty stack", func(t *testing.T) {
+		_, err := New[int]().Peek()
+		utils.ValidateResult(t, err, mutexstack.ErrEmpty)
+	})
+}
+
+func TestLenIsEmptyAndClear(t *testing.T) {
+	t.Run("Len counts pushed items", testCase(func(t *testing.T, c *testContext) {
+		utils.ValidateResult(t, c.stack.Len(), len(items))
+		utils.ValidateResult(t, c.stack.IsEmpty(), false)
+	}))
+
+	t.Run("Clear empties the stack", testCase(func(t *testing.T, c *testContext) {
+		c.stack.Clear()
+		utils.ValidateResult(t, c.stack.Len(), 0)
+		utils.ValidateResult(t, c.stack.IsEmpty(), true)
+	}))
+}
+
+type tagged struct {
+	producer, seq int
+}
+
+// TestConcurrent pushes and pops from many goroutines at once, recycling items
+// through the stack so that tops are replaced while CASes are in flight. Every
+// item must be popped exactly once.
+func TestConcurrent(t *testing.T) {
+	const workers, perWorker, rounds = 8, 1000, 20
+
+	s := New[tagged]()
+	popped := make([][]tagged, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for r := 0; r < rounds; r++ {
+				for i := 0; i < perWorker; i++ {
+					s.Push(tagged{w, r*perWorker + i})
+				}
+
+				for i := 0; i < perWorker; i++ {
+					item, ok := s.TryPop()
+					if !ok {
+						runtime.Gosched()
+						i--
+						continue
+					}
+					popped[w] = append(popped[w], item)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[tagged]bool)
+	for _, history := range popped {
+		for _, item := range history {
+			if seen[item] {
+				t.Fatalf("Item %v was popped twice", item)
+			}
+			seen[item] = true
+		}
+	}
+
+	utils.ValidateResult(t, len(seen), workers*perWorker*rounds)
+	utils.ValidateResult(t, s.IsEmpty(), true)
+	utils.ValidateResult(t, s.Len(), 0)
+}
+
+type lifo interface {
+	TryPop() (int, bool)
+}
+
+func benchmarkLIFO(b *testing.B, s lifo, push func(int)) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				push(i)
+			} else {
+				s.TryPop()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("mutex/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			s := mutexstack.NewOf[int]()
+			benchmarkLIFO(b, s, func(i int) { s.Push(i) })
+		})
+
+		b.Run(fmt.Sprintf("lockfree/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			s := New[int]()
+			benchmarkLIFO(b, s, s.Push)
+		})
+	}
+}
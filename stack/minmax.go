@@ -0,0 +1,123 @@
+package stack
+
+import (
+	"cmp"
+	"sync"
+)
+
+// minMaxNode extends the stack node with links to the nodes holding the
+// smallest and largest items at or below it. Those links form the min and max
+// chains: popping a node restores the extremes of the stack beneath it
+// without searching.
+type minMaxNode[T any] struct {
+	item T
+	next *minMaxNode[T]
+	min  *minMaxNode[T]
+	max  *minMaxNode[T]
+}
+
+// MinMax is a stack that also reports its smallest and largest items. Push,
+// Pop, Min and Max are all O(1), and Push allocates a single node.
+type MinMax[T any] struct {
+	sp   *minMaxNode[T]
+	len  int
+	less func(a, b T) bool
+	mu   sync.Mutex
+}
+
+func NewMinMax[T cmp.Ordered]() *MinMax[T] {
+	return NewMinMaxFunc(cmp.Less[T])
+}
+
+// NewMinMaxFunc creates a MinMax stack ordered by less, for item types
+// without a natural order. Among equal items, Min and Max report the one
+// pushed first.
+func NewMinMaxFunc[T any](less func(a, b T) bool) *MinMax[T] {
+	return &MinMax[T]{less: less}
+}
+
+func (s *MinMax[T]) Push(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := &minMaxNode[T]{item: item, next: s.sp}
+	n.min, n.max = n, n
+
+	if below := s.sp; below != nil {
+		if !s.less(item, below.min.item) {
+			n.min = below.min
+		}
+
+		if !s.less(below.max.item, item) {
+			n.max = below.max
+		}
+	}
+
+	s.sp = n
+	s.len++
+}
+
+// Pop removes and returns the top item. It returns ErrEmpty if the stack is
+// empty.
+func (s *MinMax[T]) Pop() (T, error) {
+	item, ok := s.TryPop()
+	if !ok {
+		return item, ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *MinMax[T]) TryPop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, false
+	}
+
+	removed := s.sp
+	s.sp = removed.next
+	s.len--
+
+	removed.next, removed.min, removed.max = nil, nil, nil
+	return removed.item, true
+}
+
+// Peek returns the top item without removing it. It returns ErrEmpty if the
+// stack is empty.
+func (s *MinMax[T]) Peek() (T, error) {
+	return s.top(func(n *minMaxNode[T]) *minMaxNode[T] { return n })
+}
+
+// Min returns the smallest item. It returns ErrEmpty if the stack is empty.
+func (s *MinMax[T]) Min() (T, error) {
+	return s.top(func(n *minMaxNode[T]) *minMaxNode[T] { return n.min })
+}
+
+// Max returns the largest item. It returns ErrEmpty if the stack is empty.
+func (s *MinMax[T]) Max() (T, error) {
+	return s.top(func(n *minMaxNode[T]) *minMaxNode[T] { return n.max })
+}
+
+func (s *MinMax[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.len
+}
+
+func (s *MinMax[T]) IsEmpty() bool { return s.Len() == 0 }
+
+func (s *MinMax[T]) top(pick func(*minMaxNode[T]) *minMaxNode[T]) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return pick(s.sp).item, nil
+}
@@ -0,0 +1,106 @@
+package stack
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestMinMax(t *testing.T) {
+	t.Run("Restores the extremes beneath a popped item", func(t *testing.T) {
+		s := NewMinMax[int]()
+		for _, item := range []int{5, 2, 8, 1, 9} {
+			s.Push(item)
+		}
+
+		wantMin := []int{1, 1, 2, 2, 5}
+		wantMax := []int{9, 8, 8, 5, 5}
+
+		for i := range wantMin {
+			min, _ := s.Min()
+			max, _ := s.Max()
+			utils.ValidateResult(t, min, wantMin[i])
+			utils.ValidateResult(t, max, wantMax[i])
+			s.Pop()
+		}
+
+		_, err := s.Min()
+		utils.ValidateResult(t, err, ErrEmpty)
+
+		_, err = s.Max()
+		utils.ValidateResult(t, err, ErrEmpty)
+
+		_, err = s.Pop()
+		utils.ValidateResult(t, err, ErrEmpty)
+	})
+
+	t.Run("Orders items by the comparator", func(t *testing.T) {
+		s := NewMinMaxFunc(func(a, b string) bool { return len(a) < len(b) })
+		for _, item := range []string{"ccc", "a", "bb"} {
+			s.Push(item)
+		}
+
+		min, _ := s.Min()
+		max, _ := s.Max()
+		utils.ValidateResult(t, min, "a")
+		utils.ValidateResult(t, max, "ccc")
+	})
+
+	t.Run("Reports the first pushed of equal extremes", func(t *testing.T) {
+		s := NewMinMaxFunc(func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) })
+		s.Push("A")
+		s.Push("a")
+
+		min, _ := s.Min()
+		max, _ := s.Max()
+		utils.ValidateResult(t, min, "A")
+		utils.ValidateResult(t, max, "A")
+	})
+
+	t.Run("Matches a slice under random pushes and pops", func(t *testing.T) {
+		s := NewMinMax[int]()
+		var model []int
+		r := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 10000; i++ {
+			if r.Intn(3) == 0 && len(model) > 0 {
+				got, _ := s.Pop()
+				utils.ValidateResult(t, got, model[len(model)-1])
+				model = model[:len(model)-1]
+			} else {
+				item := r.Intn(100)
+				s.Push(item)
+				model = append(model, item)
+			}
+
+			utils.ValidateResult(t, s.Len(), len(model))
+
+			if len(model) > 0 {
+				min, _ := s.Min()
+				max, _ := s.Max()
+				utils.ValidateResult(t, min, slices.Min(model))
+				utils.ValidateResult(t, max, slices.Max(model))
+			}
+		}
+	})
+
+	t.Run("Allocates one node per push and nothing else", func(t *testing.T) {
+		s := NewMinMax[int]()
+		for i := 0; i < 1000; i++ {
+			s.Push(i)
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			s.Push(-1)
+			s.Min()
+			s.Max()
+			s.Peek()
+			s.Pop()
+		})
+
+		utils.ValidateResult(t, allocs, float64(1))
+	})
+}
@@ -1,42 +1,175 @@
 package stack
 
-import "sync"
+import (
+	"errors"
+	"sync"
 
-type node struct {
-	item interface{}
-	next *node
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/metrics"
+)
+
+var ErrEmpty = errors.New("stack is empty")
+
+type node[T any] struct {
+	item T
+	next *node[T]
+}
+
+type options struct {
+	observer metrics.Observer
+}
+
+type Option func(*options)
+
+// WithObserver reports every Push and Pop to o.
+func WithObserver(o metrics.Observer) Option {
+	return func(opts *options) { opts.observer = o }
+}
+
+// Stack is a LIFO stack that is safe for concurrent use.
+type Stack[T any] struct {
+	sp      *node[T]
+	len     int
+	tracker *metrics.Tracker[*node[T]]
+	mu      sync.Mutex
+}
+
+// New creates a stack of arbitrary items, as before the stack was generic.
+// Use NewOf for a stack of a single item type.
+func New(opts ...Option) *Stack[any] {
+	return NewOf[any](opts...)
+}
+
+func NewOf[T any](opts ...Option) *Stack[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Stack[T]{tracker: metrics.NewTracker[*node[T]](o.observer)}
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sp = &node[T]{item: item, next: s.sp}
+	s.len++
+	s.tracker.Inserted(s.sp, s.len)
 }
 
-type stack struct {
-	sp *node
-	mu sync.Mutex
+// Pop removes and returns the top item. It returns ErrEmpty if the stack is
+// empty.
+func (s *Stack[T]) Pop() (T, error) {
+	item, ok := s.TryPop()
+	if !ok {
+		return item, ErrEmpty
+	}
+
+	return item, nil
 }
 
-func New() *stack {
-	return new(stack)
+func (s *Stack[T]) TryPop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, false
+	}
+
+	return s.pop(), true
 }
 
-func (s *stack) Push(item interface{}) {
-	s.sp = &node{item, s.sp}
+// PopN removes up to n items under a single lock, top first.
+func (s *Stack[T]) PopN(n int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.popN(n)
 }
 
-func (s *stack) Pop() interface{} {
+// DrainTo fills dst with as many items as fit, top first, and returns how many
+// it popped.
+func (s *Stack[T]) DrainTo(dst []T) int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.sp.next != nil {
-		item := s.sp.item
-		s.sp = s.sp.next
-		return item
+	i := 0
+	for ; i < len(dst) && s.sp != nil; i++ {
+		dst[i] = s.pop()
 	}
 
-	return nil
+	return i
 }
 
-func (s *stack) Peek() interface{} {
-	if s.sp != nil {
-		return s.sp.item
+func (s *Stack[T]) DrainAll() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.popN(s.len)
+}
+
+// Peek returns the top item without removing it. It returns ErrEmpty if the
+// stack is empty.
+func (s *Stack[T]) Peek() (T, error) {
+	item, ok := s.TryPeek()
+	if !ok {
+		return item, ErrEmpty
+	}
+
+	return item, nil
+}
+
+func (s *Stack[T]) TryPeek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sp == nil {
+		var zero T
+		return zero, false
+	}
+
+	return s.sp.item, true
+}
+
+func (s *Stack[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.len
+}
+
+func (s *Stack[T]) IsEmpty() bool { return s.Len() == 0 }
+
+func (s *Stack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tracker != nil {
+		s.popN(s.len)
+		return
+	}
+
+	s.sp = nil
+	s.len = 0
+}
+
+func (s *Stack[T]) pop() T {
+	removed := s.sp
+	s.sp = removed.next
+	removed.next = nil
+	s.len--
+	s.tracker.Removed(removed, s.len)
+
+	return removed.item
+}
+
+func (s *Stack[T]) popN(n int) []T {
+	items := make([]T, 0, max(min(n, s.len), 0))
+
+	for len(items) < n && s.sp != nil {
+		items = append(items, s.pop())
 	}
 
-	return nil
+	return items
 }
@@ -2,18 +2,21 @@ package stack
 
 import (
 	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+	"sync"
 	"testing"
+	"testing/quick"
+	"time"
 )
 
 var items = []interface{}{1, "string"}
 
 type testContext struct {
-	stack          *stack
+	stack          *Stack[interface{}]
 	itemsLastIndex int
 }
 
 func (c *testContext) beforeEach() {
-	s := new(stack)
+	s := New()
 
 	for _, item := range items {
 		s.Push(item)
@@ -55,11 +58,28 @@ func TestPop(t *testing.T) {
 		var got, want interface{}
 
 		for i := 0; i < c.itemsLastIndex; i++ {
-			got = c.stack.Pop()
+			got, _ = c.stack.Pop()
 			want = items[c.itemsLastIndex-i]
 			utils.ValidateResult(t, got, want)
 		}
 	}))
+
+	t.Run("Pop returns the only item of a one-item stack", func(t *testing.T) {
+		s := NewOf[int]()
+		s.Push(1)
+
+		got, err := s.Pop()
+		utils.ValidateResult(t, got, 1)
+		utils.ValidateResult(t, err, nil)
+	})
+
+	t.Run("Pop returns ErrEmpty on an empty stack", func(t *testing.T) {
+		s := NewOf[int]()
+
+		got, err := s.Pop()
+		utils.ValidateResult(t, got, 0)
+		utils.ValidateResult(t, err, ErrEmpty)
+	})
 }
 
 func TestPeek(t *testing.T) {
@@ -67,10 +87,243 @@ func TestPeek(t *testing.T) {
 		var got interface{}
 
 		for i := 0; i < 2; i++ {
-			got = c.stack.Peek()
+			got, _ = c.stack.Peek()
 		}
 
 		want := items[c.itemsLastIndex]
 		utils.ValidateResult(t, got, want)
 	}))
+
+	t.Run("Peek returns ErrEmpty on an empty stack", func(t *testing.T) {
+		_, err := NewOf[int]().Peek()
+		utils.ValidateResult(t, err, ErrEmpty)
+	})
+}
+
+func TestTryPop(t *testing.T) {
+	t.Run("Returns items in LIFO order", testCase(func(t *testing.T, c *testContext) {
+		for i := range items {
+			got, ok := c.stack.TryPop()
+			if !ok {
+				t.Fatalf("Expected item %d to be popped but the stack was empty", i)
+			}
+
+			want := items[c.itemsLastIndex-i]
+			utils.ValidateResult(t, got, want)
+		}
+	}))
+
+	t.Run("Distinguishes a stored nil from an empty stack", func(t *testing.T) {
+		s := New()
+		s.Push(nil)
+
+		_, ok := s.TryPop()
+		utils.ValidateResult(t, ok, true)
+
+		_, ok = s.TryPop()
+		utils.ValidateResult(t, ok, false)
+	})
+}
+
+func TestLen(t *testing.T) {
+	t.Run("Counts pushed items", testCase(func(t *testing.T, c *testContext) {
+		got := c.stack.Len()
+		want := len(items)
+		utils.ValidateResult(t, got, want)
+	}))
+
+	t.Run("Decrements on pop", testCase(func(t *testing.T, c *testContext) {
+		c.stack.TryPop()
+		got := c.stack.Len()
+		want := len(items) - 1
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestIsEmpty(t *testing.T) {
+	t.Run("True when empty", func(t *testing.T) {
+		s := New()
+		got := s.IsEmpty()
+		want := true
+		utils.ValidateResult(t, got, want)
+	})
+
+	t.Run("False when not empty", testCase(func(t *testing.T, c *testContext) {
+		got := c.stack.IsEmpty()
+		want := false
+		utils.ValidateResult(t, got, want)
+	}))
+}
+
+func TestClear(t *testing.T) {
+	t.Run("Removes all items", testCase(func(t *testing.T, c *testContext) {
+		c.stack.Clear()
+
+		utils.ValidateResult(t, c.stack.Len(), 0)
+		utils.ValidateResult(t, c.stack.sp, (*node[interface{}])(nil))
+	}))
+}
+
+func TestPopN(t *testing.T) {
+	t.Run("Returns up to n items in LIFO order", testCase(func(t *testing.T, c *testContext) {
+		got := c.stack.PopN(1)
+		utils.ValidateResult(t, len(got), 1)
+		utils.ValidateResult(t, got[0], items[c.itemsLastIndex])
+		utils.ValidateResult(t, c.stack.Len(), len(items)-1)
+	}))
+
+	t.Run("Stops when the stack runs out", testCase(func(t *testing.T, c *testContext) {
+		got := c.stack.PopN(len(items) + 5)
+		utils.ValidateResult(t, len(got), len(items))
+		utils.ValidateResult(t, c.stack.IsEmpty(), true)
+	}))
+}
+
+func TestDrainTo(t *testing.T) {
+	t.Run("Fills the destination and reports how many items were drained", testCase(func(t *testing.T, c *testContext) {
+		dst := make([]interface{}, len(items)+1)
+		got := c.stack.DrainTo(dst)
+
+		utils.ValidateResult(t, got, len(items))
+		utils.ValidateResult(t, dst[0], items[c.itemsLastIndex])
+		utils.ValidateResult(t, dst[len(items)], nil)
+	}))
+}
+
+func TestDrainAll(t *testing.T) {
+	t.Run("Returns every item in LIFO order", testCase(func(t *testing.T, c *testContext) {
+		got := c.stack.DrainAll()
+
+		utils.ValidateResult(t, len(got), len(items))
+		for i := range items {
+			utils.ValidateResult(t, got[i], items[c.itemsLastIndex-i])
+		}
+		utils.ValidateResult(t, c.stack.IsEmpty(), true)
+	}))
+}
+
+type recordingObserver struct {
+	sizes  []int
+	waited []time.Duration
+}
+
+func (o *recordingObserver) OnInsert(size int) {
+	o.sizes = append(o.sizes, size)
+}
+
+func (o *recordingObserver) OnRemove(size int, waited time.Duration) {
+	o.sizes = append(o.sizes, -size)
+	o.waited = append(o.waited, waited)
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("Reports pushes and pops with the resulting size", func(t *testing.T) {
+		o := &recordingObserver{}
+		s := NewOf[int](WithObserver(o))
+
+		s.Push(1)
+		s.Push(2)
+		time.Sleep(time.Millisecond)
+		s.Pop()
+
+		want := []int{1, 2, -1}
+		utils.ValidateResult(t, len(o.sizes), len(want))
+		for i := range want {
+			utils.ValidateResult(t, o.sizes[i], want[i])
+		}
+
+		if o.waited[0] < time.Millisecond {
+			t.Errorf("Expected the popped item to have waited at least 1ms but got %v", o.waited[0])
+		}
+	})
+}
+
+// TestMatchesSliceModel runs random sequences of operations against both the
+// stack and a slice and checks that every result agrees.
+func TestMatchesSliceModel(t *testing.T) {
+	property := func(ops []uint8, values []int) bool {
+		s := NewOf[int]()
+		var model []int
+
+		for i, op := range ops {
+			switch op % 4 {
+			case 0, 1:
+				value := 0
+				if len(values) > 0 {
+					value = values[i%len(values)]
+				}
+
+				s.Push(value)
+				model = append(model, value)
+			case 2:
+				got, err := s.Pop()
+
+				if len(model) == 0 {
+					if err != ErrEmpty {
+						return false
+					}
+					continue
+				}
+
+				want := model[len(model)-1]
+				model = model[:len(model)-1]
+
+				if err != nil || got != want {
+					return false
+				}
+			case 3:
+				got, ok := s.TryPeek()
+
+				if ok != (len(model) > 0) || ok && got != model[len(model)-1] {
+					return false
+				}
+			}
+
+			if s.Len() != len(model) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConcurrentPushAndPop(t *testing.T) {
+	s := NewOf[int]()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				s.Push(j)
+				s.Peek()
+			}
+		}()
+	}
+	wg.Wait()
+
+	utils.ValidateResult(t, s.Len(), 8000)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				if _, err := s.Pop(); err != nil {
+					t.Errorf("Expected an item but got %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	utils.ValidateResult(t, s.IsEmpty(), true)
 }
@@ -0,0 +1,142 @@
+// Package traverse walks trees and graphs depth-first without recursion.
+//
+// The walks keep their path on a heap-allocated stack instead of the
+// goroutine's call stack, so inputs millions of levels deep are walked in
+// memory proportional to their depth, with no risk of overflowing the
+// goroutine stack.
+package traverse
+
+import "github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+
+// Action tells a walk how to proceed after a callback.
+type Action int
+
+const (
+	Continue Action = iota
+	// SkipChildren prunes the subtree below the node just entered. Its Exit
+	// callback still runs.
+	SkipChildren
+	// Stop ends the walk at once. No further callbacks run, including the
+	// Exit callbacks of the nodes on the current path.
+	Stop
+)
+
+// Visitor holds the callbacks of a walk, both optional. Enter runs when a node
+// is reached, before its children, which gives a pre-order visit. Exit runs
+// after all of its children are done, which gives a post-order visit. Depth
+// counts from 0 at the root.
+type Visitor[N any] struct {
+	Enter func(node N, depth int) Action
+	Exit  func(node N, depth int) Action
+}
+
+type frame[N any] struct {
+	node     N
+	depth    int
+	children []N
+	next     int
+}
+
+// Walk visits the tree below root depth-first, asking children for the
+// children of each node in the order they should be visited. It reports
+// whether the walk ran to completion rather than being stopped.
+func Walk[N any](root N, children func(N) []N, v Visitor[N]) bool {
+	return walk(root, children, v, func(N) bool { return true })
+}
+
+// WalkGraph is Walk for graphs, which may share nodes or contain cycles. A
+// node reached a second time is skipped, so each node is entered and exited
+// once, as part of the depth-first spanning tree of the nodes reachable from
+// root.
+func WalkGraph[N comparable](root N, neighbours func(N) []N, v Visitor[N]) bool {
+	seen := make(map[N]bool)
+
+	return walk(root, neighbours, v, func(node N) bool {
+		if seen[node] {
+			return false
+		}
+
+		seen[node] = true
+		return true
+	})
+}
+
+func walk[N any](root N, children func(N) []N, v Visitor[N], first func(N) bool) bool {
+	path := stack.NewOf[*frame[N]]()
+
+	enter := func(node N, depth int) bool {
+		action := Continue
+		if v.Enter != nil {
+			action = v.Enter(node, depth)
+		}
+
+		if action == Stop {
+			return false
+		}
+
+		f := &frame[N]{node: node, depth: depth}
+		if action != SkipChildren {
+			f.children = children(node)
+		}
+
+		path.Push(f)
+		return true
+	}
+
+	first(root)
+	if !enter(root, 0) {
+		return false
+	}
+
+	for {
+		top, ok := path.TryPeek()
+		if !ok {
+			return true
+		}
+
+		if top.next < len(top.children) {
+			child := top.children[top.next]
+			top.next++
+
+			if first(child) && !enter(child, top.depth+1) {
+				return false
+			}
+
+			continue
+		}
+
+		path.Pop()
+
+		if v.Exit != nil && v.Exit(top.node, top.depth) == Stop {
+			return false
+		}
+	}
+}
+
+// InOrder visits the binary tree below root in order: each node's left
+// subtree, then the node, then its right subtree. left and right report a
+// node's children, with false for a missing one. Returning false from visit
+// stops the walk, in which case InOrder returns false too.
+func InOrder[N any](root N, left, right func(N) (N, bool), visit func(N) bool) bool {
+	pending := stack.NewOf[N]()
+
+	node, ok := root, true
+
+	for {
+		for ok {
+			pending.Push(node)
+			node, ok = left(node)
+		}
+
+		next, err := pending.Pop()
+		if err != nil {
+			return true
+		}
+
+		if !visit(next) {
+			return false
+		}
+
+		node, ok = right(next)
+	}
+}
@@ -0,0 +1,223 @@
+package traverse
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+// deep is the depth of the chain-shaped inputs. Walking them recursively
+// takes at least tens of bytes of goroutine stack per level, well over the
+// cap limitStack sets.
+const deep = 1_500_000
+
+// limitStack caps goroutine stacks at 16 MB for the rest of the test, so that
+// a walk which recursed would crash instead of quietly using a lot of memory.
+func limitStack(t *testing.T) {
+	previous := debug.SetMaxStack(16 << 20)
+	t.Cleanup(func() { debug.SetMaxStack(previous) })
+}
+
+type tree struct {
+	name     string
+	children []*tree
+}
+
+func node(name string, children ...*tree) *tree {
+	return &tree{name, children}
+}
+
+var sample = node("a",
+	node("b", node("d"), node("e")),
+	node("c", node("f")),
+)
+
+func childrenOf(t *tree) []*tree { return t.children }
+
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) visitor(enter, exit func(*tree) Action) Visitor[*tree] {
+	return Visitor[*tree]{
+		Enter: func(t *tree, depth int) Action {
+			r.events = append(r.events, fmt.Sprintf("+%s%d", t.name, depth))
+			return enter(t)
+		},
+		Exit: func(t *tree, depth int) Action {
+			r.events = append(r.events, "-"+t.name)
+			return exit(t)
+		},
+	}
+}
+
+func proceed(*tree) Action { return Continue }
+
+func TestWalk(t *testing.T) {
+	t.Run("Enters in pre-order and exits in post-order", func(t *testing.T) {
+		r := &recorder{}
+		done := Walk(sample, childrenOf, r.visitor(proceed, proceed))
+
+		utils.ValidateResult(t, done, true)
+		utils.ValidateResult(t, strings.Join(r.events, " "), "+a0 +b1 +d2 -d +e2 -e -b +c1 +f2 -f -c -a")
+	})
+
+	t.Run("Prunes skipped subtrees", func(t *testing.T) {
+		r := &recorder{}
+		skipB := func(t *tree) Action {
+			if t.name == "b" {
+				return SkipChildren
+			}
+			return Continue
+		}
+
+		Walk(sample, childrenOf, r.visitor(skipB, proceed))
+		utils.ValidateResult(t, strings.Join(r.events, " "), "+a0 +b1 -b +c1 +f2 -f -c -a")
+	})
+
+	t.Run("Stops on entering", func(t *testing.T) {
+		r := &recorder{}
+		stopAtE := func(t *tree) Action {
+			if t.name == "e" {
+				return Stop
+			}
+			return Continue
+		}
+
+		done := Walk(sample, childrenOf, r.visitor(stopAtE, proceed))
+		utils.ValidateResult(t, done, false)
+		utils.ValidateResult(t, strings.Join(r.events, " "), "+a0 +b1 +d2 -d +e2")
+	})
+
+	t.Run("Stops on exiting", func(t *testing.T) {
+		r := &recorder{}
+		stopAfterB := func(t *tree) Action {
+			if t.name == "b" {
+				return Stop
+			}
+			return Continue
+		}
+
+		done := Walk(sample, childrenOf, r.visitor(proceed, stopAfterB))
+		utils.ValidateResult(t, done, false)
+		utils.ValidateResult(t, strings.Join(r.events, " "), "+a0 +b1 +d2 -d +e2 -e -b")
+	})
+
+	t.Run("Walks a chain too deep for recursion", func(t *testing.T) {
+		limitStack(t)
+
+		chain := func(i int) []int {
+			if i < deep {
+				return []int{i + 1}
+			}
+			return nil
+		}
+
+		deepest, exits := 0, 0
+		Walk(0, chain, Visitor[int]{
+			Enter: func(_ int, depth int) Action {
+				deepest = max(deepest, depth)
+				return Continue
+			},
+			Exit: func(int, int) Action {
+				exits++
+				return Continue
+			},
+		})
+
+		utils.ValidateResult(t, deepest, deep)
+		utils.ValidateResult(t, exits, deep+1)
+	})
+}
+
+func TestWalkGraph(t *testing.T) {
+	t.Run("Visits each node of a cyclic graph once", func(t *testing.T) {
+		graph := map[string][]string{
+			"a": {"b", "c"},
+			"b": {"c", "a"},
+			"c": {"a", "d"},
+			"d": {"b"},
+		}
+
+		var order []string
+		WalkGraph("a", func(n string) []string { return graph[n] }, Visitor[string]{
+			Enter: func(n string, _ int) Action {
+				order = append(order, n)
+				return Continue
+			},
+		})
+
+		utils.ValidateResult(t, strings.Join(order, " "), "a b c d")
+	})
+
+	t.Run("Walks a long cycle", func(t *testing.T) {
+		limitStack(t)
+
+		cycle := func(i int) []int { return []int{(i + 1) % deep} }
+
+		count := 0
+		done := WalkGraph(0, cycle, Visitor[int]{
+			Exit: func(int, int) Action {
+				count++
+				return Continue
+			},
+		})
+
+		utils.ValidateResult(t, done, true)
+		utils.ValidateResult(t, count, deep)
+	})
+}
+
+func TestInOrder(t *testing.T) {
+	t.Run("Visits left subtree, node, right subtree", func(t *testing.T) {
+		lefts := map[int]int{4: 2, 2: 1, 6: 5}
+		rights := map[int]int{4: 6, 2: 3, 6: 7}
+		lookup := func(m map[int]int) func(int) (int, bool) {
+			return func(n int) (int, bool) { child, ok := m[n]; return child, ok }
+		}
+
+		var got []int
+		done := InOrder(4, lookup(lefts), lookup(rights), func(n int) bool {
+			got = append(got, n)
+			return true
+		})
+
+		utils.ValidateResult(t, done, true)
+		utils.ValidateResult(t, fmt.Sprint(got), "[1 2 3 4 5 6 7]")
+	})
+
+	t.Run("Stops early", func(t *testing.T) {
+		left := func(n int) (int, bool) { return n - 1, n > 0 }
+		none := func(int) (int, bool) { return 0, false }
+
+		var got []int
+		done := InOrder(5, left, none, func(n int) bool {
+			got = append(got, n)
+			return n < 2
+		})
+
+		utils.ValidateResult(t, done, false)
+		utils.ValidateResult(t, fmt.Sprint(got), "[0 1 2]")
+	})
+
+	t.Run("Walks a left spine too deep for recursion", func(t *testing.T) {
+		limitStack(t)
+
+		left := func(n int) (int, bool) { return n - 1, n > 0 }
+		none := func(int) (int, bool) { return 0, false }
+
+		want := 0
+		InOrder(deep, left, none, func(n int) bool {
+			if n != want {
+				t.Fatalf("Expected %d but got %d", want, n)
+			}
+			want++
+			return true
+		})
+
+		utils.ValidateResult(t, want, deep+1)
+	})
+}
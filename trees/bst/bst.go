@@ -0,0 +1,278 @@
+// Package bst implements an unbalanced binary search tree holding a set of
+// items. Every operation walks a single path from the root, so it costs
+// O(height): O(log n) for random insertion orders, but O(n) when items are
+// inserted in sorted order. All walks are iterative, so even such degenerate
+// trees cannot overflow the goroutine stack.
+package bst
+
+import (
+	"cmp"
+	"fmt"
+	"sync"
+)
+
+type node[T any] struct {
+	item  T
+	left  *node[T]
+	right *node[T]
+}
+
+type Tree[T any] struct {
+	root *node[T]
+	len  int
+	less func(a, b T) bool
+	mu   sync.Mutex
+}
+
+func New[T cmp.Ordered]() *Tree[T] {
+	return NewFunc(cmp.Less[T])
+}
+
+// NewFunc creates a tree ordered by less. Items for which neither is less
+// than the other are considered equal, so the tree holds at most one of them.
+func NewFunc[T any](less func(a, b T) bool) *Tree[T] {
+	return &Tree[T]{less: less}
+}
+
+// Insert adds item and reports whether it did; an equal item already in the
+// tree is left in place.
+func (t *Tree[T]) Insert(item T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	link := &t.root
+	for *link != nil {
+		switch n := *link; {
+		case t.less(item, n.item):
+			link = &n.left
+		case t.less(n.item, item):
+			link = &n.right
+		default:
+			return false
+		}
+	}
+
+	*link = &node[T]{item: item}
+	t.len++
+	return true
+}
+
+// Delete removes the item equal to item and reports whether there was one.
+func (t *Tree[T]) Delete(item T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	link := t.find(item)
+	if *link == nil {
+		return false
+	}
+
+	n := *link
+
+	switch {
+	case n.left == nil:
+		// A leaf, or a node with only a right child, is replaced by that
+		// child.
+		*link = n.right
+	case n.right == nil:
+		*link = n.left
+	default:
+		// A node with two children takes the item of its in-order
+		// successor, the leftmost node of its right subtree, which has no
+		// left child and so is unlinked like the cases above.
+		successor := &n.right
+		for (*successor).left != nil {
+			successor = &(*successor).left
+		}
+
+		n.item = (*successor).item
+		*successor = (*successor).right
+	}
+
+	t.len--
+	return true
+}
+
+// Search returns the item equal to item, which may differ from it if less
+// only compares part of the items, such as a key.
+func (t *Tree[T]) Search(item T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n := *t.find(item); n != nil {
+		return n.item, true
+	}
+
+	var zero T
+	return zero, false
+}
+
+func (t *Tree[T]) Min() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return extreme(t.root, func(n *node[T]) *node[T] { return n.left })
+}
+
+func (t *Tree[T]) Max() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return extreme(t.root, func(n *node[T]) *node[T] { return n.right })
+}
+
+// Successor returns the smallest item greater than item, which need not be
+// in the tree itself.
+func (t *Tree[T]) Successor(item T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var found *node[T]
+
+	for n := t.root; n != nil; {
+		if t.less(item, n.item) {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return itemOf(found)
+}
+
+// Predecessor returns the largest item less than item, which need not be in
+// the tree itself.
+func (t *Tree[T]) Predecessor(item T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var found *node[T]
+
+	for n := t.root; n != nil; {
+		if t.less(n.item, item) {
+			found = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	return itemOf(found)
+}
+
+// Height counts the nodes on the longest path from the root down, so an empty
+// tree has height 0 and a single item height 1.
+func (t *Tree[T]) Height() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	height := 0
+	level := []*node[T]{}
+	if t.root != nil {
+		level = append(level, t.root)
+	}
+
+	for len(level) > 0 {
+		height++
+
+		var next []*node[T]
+		for _, n := range level {
+			if n.left != nil {
+				next = append(next, n.left)
+			}
+			if n.right != nil {
+				next = append(next, n.right)
+			}
+		}
+
+		level = next
+	}
+
+	return height
+}
+
+// Validate checks the invariants of the tree: that an in-order walk yields
+// strictly increasing items, and that Len counts them all. It returns an error
+// describing the first violation found.
+func (t *Tree[T]) Validate() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := 0
+	var previous *node[T]
+
+	it := newInOrder(t.root)
+	for n := it.next(); n != nil; n = it.next() {
+		if previous != nil && !t.less(previous.item, n.item) {
+			return fmt.Errorf("items out of order: %v is followed by %v", previous.item, n.item)
+		}
+
+		previous = n
+		count++
+	}
+
+	if count != t.len {
+		return fmt.Errorf("length is %d but the tree holds %d items", t.len, count)
+	}
+
+	return nil
+}
+
+func (t *Tree[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.len
+}
+
+func (t *Tree[T]) IsEmpty() bool { return t.Len() == 0 }
+
+func (t *Tree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.root = nil
+	t.len = 0
+}
+
+// find returns the link that points to the node equal to item, or the nil
+// link where such a node would be inserted.
+func (t *Tree[T]) find(item T) **node[T] {
+	link := &t.root
+
+	for *link != nil {
+		switch n := *link; {
+		case t.less(item, n.item):
+			link = &n.left
+		case t.less(n.item, item):
+			link = &n.right
+		default:
+			return link
+		}
+	}
+
+	return link
+}
+
+func extreme[T any](n *node[T], child func(*node[T]) *node[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+
+	for child(n) != nil {
+		n = child(n)
+	}
+
+	return n.item, true
+}
+
+func itemOf[T any](n *node[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+
+	return n.item, true
+}
@@ -0,0 +1,271 @@
+package bst
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func collect[T any](it Iterator[T]) []T {
+	var items []T
+	for item, ok := it.Next(); ok; item, ok = it.Next() {
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func validateSlices(t *testing.T, got, want []int) {
+	t.Helper()
+
+	utils.ValidateResult(t, len(got), len(want))
+	for i := range min(len(got), len(want)) {
+		utils.ValidateResult(t, got[i], want[i])
+	}
+}
+
+// Recursive references for the iterators and Height, fine for the small trees
+// they are used on.
+
+func preOrder(n *node[int], items []int) []int {
+	if n == nil {
+		return items
+	}
+
+	items = append(items, n.item)
+	return preOrder(n.right, preOrder(n.left, items))
+}
+
+func postOrder(n *node[int], items []int) []int {
+	if n == nil {
+		return items
+	}
+
+	items = postOrder(n.right, postOrder(n.left, items))
+	return append(items, n.item)
+}
+
+func levelOrder(root *node[int]) []int {
+	var items []int
+
+	for depth := 0; ; depth++ {
+		level := atDepth(root, depth, nil)
+		if len(level) == 0 {
+			return items
+		}
+
+		items = append(items, level...)
+	}
+}
+
+func atDepth(n *node[int], depth int, items []int) []int {
+	if n == nil {
+		return items
+	}
+
+	if depth == 0 {
+		return append(items, n.item)
+	}
+
+	return atDepth(n.right, depth-1, atDepth(n.left, depth-1, items))
+}
+
+func height(n *node[int]) int {
+	if n == nil {
+		return 0
+	}
+
+	return 1 + max(height(n.left), height(n.right))
+}
+
+func treeOf(items ...int) *Tree[int] {
+	t := New[int]()
+	for _, item := range items {
+		t.Insert(item)
+	}
+
+	return t
+}
+
+func TestInsertAndSearch(t *testing.T) {
+	tree := treeOf(5, 3, 8)
+
+	utils.ValidateResult(t, tree.Insert(5), false)
+	utils.ValidateResult(t, tree.Len(), 3)
+
+	got, ok := tree.Search(3)
+	utils.ValidateResult(t, ok, true)
+	utils.ValidateResult(t, got, 3)
+
+	_, ok = tree.Search(4)
+	utils.ValidateResult(t, ok, false)
+}
+
+func TestSearchByKey(t *testing.T) {
+	type entry struct {
+		key   string
+		value int
+	}
+
+	tree := NewFunc(func(a, b entry) bool { return a.key < b.key })
+	tree.Insert(entry{"b", 2})
+	tree.Insert(entry{"a", 1})
+
+	got, _ := tree.Search(entry{key: "b"})
+	utils.ValidateResult(t, got.value, 2)
+}
+
+func TestDelete(t *testing.T) {
+	//        5
+	//      /   \
+	//     3     8
+	//    /     / \
+	//   1     7   9
+	//        /
+	//       6
+	items := []int{5, 3, 8, 1, 7, 9, 6}
+
+	t.Run("Removes a leaf", func(t *testing.T) {
+		tree := treeOf(items...)
+		utils.ValidateResult(t, tree.Delete(9), true)
+		validateSlices(t, preOrder(tree.root, nil), []int{5, 3, 1, 8, 7, 6})
+	})
+
+	t.Run("Replaces a node with its only child", func(t *testing.T) {
+		tree := treeOf(items...)
+		utils.ValidateResult(t, tree.Delete(3), true)
+		validateSlices(t, preOrder(tree.root, nil), []int{5, 1, 8, 7, 6, 9})
+	})
+
+	t.Run("Replaces a node with two children by its successor", func(t *testing.T) {
+		tree := treeOf(items...)
+		utils.ValidateResult(t, tree.Delete(5), true)
+		validateSlices(t, preOrder(tree.root, nil), []int{6, 3, 1, 8, 7, 9})
+	})
+
+	t.Run("Reports a missing item", func(t *testing.T) {
+		tree := treeOf(items...)
+		utils.ValidateResult(t, tree.Delete(4), false)
+		utils.ValidateResult(t, tree.Len(), len(items))
+	})
+}
+
+func TestIterators(t *testing.T) {
+	tree := treeOf(5, 3, 8, 1, 4, 9)
+
+	validateSlices(t, collect(tree.InOrder()), []int{1, 3, 4, 5, 8, 9})
+	validateSlices(t, collect(tree.PreOrder()), []int{5, 3, 1, 4, 8, 9})
+	validateSlices(t, collect(tree.PostOrder()), []int{1, 4, 3, 9, 8, 5})
+	validateSlices(t, collect(tree.LevelOrder()), []int{5, 3, 8, 1, 4, 9})
+
+	empty := New[int]()
+	validateSlices(t, collect(empty.InOrder()), nil)
+	validateSlices(t, collect(empty.PostOrder()), nil)
+}
+
+func TestDegenerateTree(t *testing.T) {
+	const n = 10000
+	tree := New[int]()
+	for i := 0; i < n; i++ {
+		tree.Insert(i)
+	}
+
+	utils.ValidateResult(t, tree.Height(), n)
+	utils.ValidateResult(t, len(collect(tree.PostOrder())), n)
+	utils.ValidateResult(t, tree.Validate(), nil)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Catches items out of order", func(t *testing.T) {
+		tree := treeOf(2, 1, 3)
+		tree.root.left.item = 4
+
+		utils.ValidateResult(t, tree.Validate().Error(), "items out of order: 4 is followed by 2")
+	})
+
+	t.Run("Catches a wrong length", func(t *testing.T) {
+		tree := treeOf(2, 1, 3)
+		tree.len++
+
+		utils.ValidateResult(t, tree.Validate().Error(), "length is 4 but the tree holds 3 items")
+	})
+}
+
+// TestRandomOperations runs generated sequences of operations against both a
+// tree and a sorted slice, checking every query and the tree's shape along
+// the way.
+func TestRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for sequence := 0; sequence < 50; sequence++ {
+		tree := New[int]()
+		var model []int
+
+		for op := 0; op < 300; op++ {
+			item := r.Intn(100)
+			i, found := slices.BinarySearch(model, item)
+
+			switch r.Intn(3) {
+			case 0:
+				utils.ValidateResult(t, tree.Insert(item), !found)
+				if !found {
+					model = slices.Insert(model, i, item)
+				}
+			case 1:
+				utils.ValidateResult(t, tree.Delete(item), found)
+				if found {
+					model = slices.Delete(model, i, i+1)
+				}
+			case 2:
+				_, ok := tree.Search(item)
+				utils.ValidateResult(t, ok, found)
+			}
+
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("Tree broke after %d operations: %v", op+1, err)
+			}
+
+			utils.ValidateResult(t, tree.Len(), len(model))
+			validateQueries(t, tree, model, r.Intn(100))
+		}
+
+		validateSlices(t, collect(tree.InOrder()), model)
+		validateSlices(t, collect(tree.PreOrder()), preOrder(tree.root, nil))
+		validateSlices(t, collect(tree.PostOrder()), postOrder(tree.root, nil))
+		validateSlices(t, collect(tree.LevelOrder()), levelOrder(tree.root))
+		utils.ValidateResult(t, tree.Height(), height(tree.root))
+	}
+}
+
+func validateQueries(t *testing.T, tree *Tree[int], model []int, probe int) {
+	t.Helper()
+
+	smallest, ok := tree.Min()
+	utils.ValidateResult(t, ok, len(model) > 0)
+	if ok {
+		utils.ValidateResult(t, smallest, model[0])
+	}
+
+	largest, ok := tree.Max()
+	if ok {
+		utils.ValidateResult(t, largest, model[len(model)-1])
+	}
+
+	// The successor is the first item above probe, and the predecessor the
+	// last one below it.
+	above, _ := slices.BinarySearch(model, probe+1)
+	successor, ok := tree.Successor(probe)
+	utils.ValidateResult(t, ok, above < len(model))
+	if ok {
+		utils.ValidateResult(t, successor, model[above])
+	}
+
+	below, _ := slices.BinarySearch(model, probe)
+	predecessor, ok := tree.Predecessor(probe)
+	utils.ValidateResult(t, ok, below > 0)
+	if ok {
+		utils.ValidateResult(t, predecessor, model[below-1])
+	}
+}
@@ -0,0 +1,155 @@
+package bst
+
+import (
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/queue"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+// Iterator yields the items of a tree one at a time. Next returns false once
+// they are exhausted. The tree must not be modified while an iterator over it
+// is in use.
+type Iterator[T any] interface {
+	Next() (T, bool)
+}
+
+// iterator adapts a function yielding nodes, nil when done, to Iterator. It
+// takes the tree's lock for every step.
+type iterator[T any] struct {
+	tree *Tree[T]
+	next func() *node[T]
+}
+
+func (it *iterator[T]) Next() (T, bool) {
+	it.tree.mu.Lock()
+	defer it.tree.mu.Unlock()
+
+	return itemOf(it.next())
+}
+
+// InOrder iterates over the items from smallest to largest.
+func (t *Tree[T]) InOrder() Iterator[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return &iterator[T]{t, newInOrder(t.root).next}
+}
+
+// PreOrder iterates over each node before its left and then its right
+// subtree. Inserting the items in this order into an empty tree rebuilds the
+// same shape.
+func (t *Tree[T]) PreOrder() Iterator[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := stack.NewOf[*node[T]]()
+	if t.root != nil {
+		pending.Push(t.root)
+	}
+
+	return &iterator[T]{t, func() *node[T] {
+		n, ok := pending.TryPop()
+		if !ok {
+			return nil
+		}
+
+		if n.right != nil {
+			pending.Push(n.right)
+		}
+		if n.left != nil {
+			pending.Push(n.left)
+		}
+
+		return n
+	}}
+}
+
+// PostOrder iterates over each node after its left and then its right
+// subtree, which visits children before their parents.
+func (t *Tree[T]) PostOrder() Iterator[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path := stack.NewOf[*node[T]]()
+	var last *node[T]
+	descend := t.root
+
+	return &iterator[T]{t, func() *node[T] {
+		for {
+			for ; descend != nil; descend = descend.left {
+				path.Push(descend)
+			}
+
+			top, ok := path.TryPeek()
+			if !ok {
+				return nil
+			}
+
+			// A node is done once its right subtree is, which is either
+			// missing or the subtree just yielded.
+			if top.right != nil && top.right != last {
+				descend = top.right
+				continue
+			}
+
+			path.TryPop()
+			last = top
+			return top
+		}
+	}}
+}
+
+// LevelOrder iterates over the items level by level from the root, left to
+// right within each level.
+func (t *Tree[T]) LevelOrder() Iterator[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := queue.NewOf[*node[T]]()
+	if t.root != nil {
+		pending.Enqueue(t.root)
+	}
+
+	return &iterator[T]{t, func() *node[T] {
+		n, ok := pending.TryDequeue()
+		if !ok {
+			return nil
+		}
+
+		if n.left != nil {
+			pending.Enqueue(n.left)
+		}
+		if n.right != nil {
+			pending.Enqueue(n.right)
+		}
+
+		return n
+	}}
+}
+
+type inOrder[T any] struct {
+	path *stack.Stack[*node[T]]
+}
+
+func newInOrder[T any](root *node[T]) *inOrder[T] {
+	it := &inOrder[T]{stack.NewOf[*node[T]]()}
+	it.pushLeftSpine(root)
+	return it
+}
+
+// next returns the node on top of the path, whose left subtree is done, after
+// queueing up its right subtree.
+func (it *inOrder[T]) next() *node[T] {
+	n, ok := it.path.TryPop()
+	if !ok {
+		return nil
+	}
+
+	it.pushLeftSpine(n.right)
+	return n
+}
+
+func (it *inOrder[T]) pushLeftSpine(n *node[T]) {
+	for ; n != nil; n = n.left {
+		it.path.Push(n)
+	}
+}
@@ -0,0 +1,247 @@
+// Package undo records the commands run against a document so they can be
+// undone and redone.
+//
+// Executed commands go on the undo stack. Undo moves the top command to the
+// redo stack and Redo moves it back; executing a new command clears the redo
+// stack, since the commands on it no longer apply to the new state. Commands
+// executed between BeginGroup and EndGroup are recorded as a single command,
+// and a command that implements Merger can absorb the one executed after it,
+// so that, say, a run of keystrokes undoes as one.
+//
+// A Manager is not safe for concurrent use. Commands and callbacks may not
+// call back into the Manager that runs them.
+package undo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/stack"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+	ErrGroupOpen     = errors.New("a group is open")
+	ErrNoGroup       = errors.New("no group is open")
+)
+
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+// Merger is implemented by commands that can absorb the command executed
+// right after them. Merge reports whether it absorbed next, in which case
+// undoing and redoing the merged command must also cover next.
+type Merger interface {
+	Merge(next Command) bool
+}
+
+// State is what a user interface needs to enable its undo and redo controls.
+type State struct {
+	CanUndo, CanRedo bool
+	UndoLen, RedoLen int
+	InGroup          bool
+}
+
+type config struct {
+	limit    int
+	onChange func(State)
+}
+
+type Option func(*config)
+
+// WithLimit keeps at most n commands on each stack, forgetting the oldest
+// first. A group counts as one command. The default of zero keeps them all.
+func WithLimit(n int) Option {
+	return func(c *config) { c.limit = n }
+}
+
+// WithOnChange calls fn with the new state after every change to it.
+func WithOnChange(fn func(State)) Option {
+	return func(c *config) { c.onChange = fn }
+}
+
+type Manager struct {
+	undo     *stack.Bounded[Command]
+	redo     *stack.Bounded[Command]
+	groups   []*group
+	onChange func(State)
+}
+
+func New(opts ...Option) *Manager {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	// Without a limit, the stacks are bounded only by what fits in an int.
+	limit := c.limit
+	if limit <= 0 {
+		limit = math.MaxInt
+	}
+
+	history := []stack.BoundedOption[Command]{
+		stack.WithCapacity[Command](limit),
+		stack.WithOverflow[Command](stack.DropOldest),
+	}
+
+	return &Manager{
+		undo:     stack.NewBounded(history...),
+		redo:     stack.NewBounded(history...),
+		onChange: c.onChange,
+	}
+}
+
+// Execute runs cmd and records it. If cmd fails, nothing is recorded and the
+// error is returned.
+func (m *Manager) Execute(cmd Command) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+
+	if len(m.groups) > 0 {
+		g := m.groups[len(m.groups)-1]
+		g.commands = append(g.commands, cmd)
+		return nil
+	}
+
+	m.record(cmd)
+	return nil
+}
+
+// Undo reverts the most recent command. If that fails, the command stays
+// where it was and the error is returned.
+func (m *Manager) Undo() error {
+	return m.move(m.undo, m.redo, ErrNothingToUndo, Command.Undo)
+}
+
+// Redo reapplies the most recently undone command. If that fails, the command
+// stays where it was and the error is returned.
+func (m *Manager) Redo() error {
+	return m.move(m.redo, m.undo, ErrNothingToRedo, Command.Do)
+}
+
+// BeginGroup starts recording commands as one. Groups nest, and only the
+// outermost EndGroup records the result.
+func (m *Manager) BeginGroup() {
+	m.groups = append(m.groups, new(group))
+	m.notify()
+}
+
+// EndGroup closes the innermost group. An empty group records nothing.
+func (m *Manager) EndGroup() error {
+	if len(m.groups) == 0 {
+		return ErrNoGroup
+	}
+
+	g := m.groups[len(m.groups)-1]
+	m.groups = m.groups[:len(m.groups)-1]
+
+	switch {
+	case len(g.commands) == 0:
+		m.notify()
+	case len(m.groups) > 0:
+		parent := m.groups[len(m.groups)-1]
+		parent.commands = append(parent.commands, g)
+	default:
+		m.record(g)
+	}
+
+	return nil
+}
+
+func (m *Manager) State() State {
+	return State{
+		CanUndo: m.undo.Len() > 0 && len(m.groups) == 0,
+		CanRedo: m.redo.Len() > 0 && len(m.groups) == 0,
+		UndoLen: m.undo.Len(),
+		RedoLen: m.redo.Len(),
+		InGroup: len(m.groups) > 0,
+	}
+}
+
+func (m *Manager) CanUndo() bool { return m.State().CanUndo }
+
+func (m *Manager) CanRedo() bool { return m.State().CanRedo }
+
+// Clear forgets all history. It does not touch open groups.
+func (m *Manager) Clear() {
+	m.undo.Clear()
+	m.redo.Clear()
+	m.notify()
+}
+
+func (m *Manager) record(cmd Command) {
+	if last, ok := m.undo.TryPeek(); !ok || !merge(last, cmd) {
+		m.undo.Push(cmd)
+	}
+
+	m.redo.Clear()
+	m.notify()
+}
+
+func merge(last, next Command) bool {
+	merger, ok := last.(Merger)
+	return ok && merger.Merge(next)
+}
+
+func (m *Manager) move(from, to *stack.Bounded[Command], errEmpty error, apply func(Command) error) error {
+	if len(m.groups) > 0 {
+		return ErrGroupOpen
+	}
+
+	cmd, err := from.Pop()
+	if err != nil {
+		return errEmpty
+	}
+
+	if err := apply(cmd); err != nil {
+		from.Push(cmd)
+		return err
+	}
+
+	to.Push(cmd)
+	m.notify()
+	return nil
+}
+
+func (m *Manager) notify() {
+	if m.onChange != nil {
+		m.onChange(m.State())
+	}
+}
+
+// group is a sequence of commands that is done and undone as one. If one of
+// them fails, those already applied are reverted, leaving the group either
+// fully applied or not at all.
+type group struct {
+	commands []Command
+}
+
+func (g *group) Do() error {
+	for i, cmd := range g.commands {
+		if err := cmd.Do(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				g.commands[j].Undo()
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *group) Undo() error {
+	for i := len(g.commands) - 1; i >= 0; i-- {
+		if err := g.commands[i].Undo(); err != nil {
+			for j := i + 1; j < len(g.commands); j++ {
+				g.commands[j].Do()
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package undo
+
+import (
+	"errors"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+type document struct {
+	text string
+}
+
+// typing appends text to a document. Consecutive typing merges into one
+// command until a space starts the next word.
+type typing struct {
+	doc  *document
+	text string
+}
+
+func (c *typing) Do() error {
+	c.doc.text += c.text
+	return nil
+}
+
+func (c *typing) Undo() error {
+	c.doc.text = c.doc.text[:len(c.doc.text)-len(c.text)]
+	return nil
+}
+
+func (c *typing) Merge(next Command) bool {
+	n, ok := next.(*typing)
+	if !ok || n.doc != c.doc || n.text == " " {
+		return false
+	}
+
+	c.text += n.text
+	return true
+}
+
+// failing fails to undo while broken is set.
+type failing struct {
+	doc    *document
+	broken bool
+}
+
+func (c *failing) Do() error {
+	c.doc.text += "!"
+	return nil
+}
+
+func (c *failing) Undo() error {
+	if c.broken {
+		return errors.New("broken")
+	}
+
+	c.doc.text = c.doc.text[:len(c.doc.text)-1]
+	return nil
+}
+
+func typeText(m *Manager, doc *document, text string) {
+	for _, r := range text {
+		m.Execute(&typing{doc, string(r)})
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	t.Run("Undoes and redoes in order", func(t *testing.T) {
+		doc := &document{}
+		m := New()
+		typeText(m, doc, "ab cd")
+
+		utils.ValidateResult(t, m.Undo(), nil)
+		utils.ValidateResult(t, doc.text, "ab")
+		utils.ValidateResult(t, m.Undo(), nil)
+		utils.ValidateResult(t, doc.text, "")
+
+		utils.ValidateResult(t, m.Redo(), nil)
+		utils.ValidateResult(t, doc.text, "ab")
+	})
+
+	t.Run("Reports when there is nothing to undo or redo", func(t *testing.T) {
+		m := New()
+
+		utils.ValidateResult(t, m.Undo(), ErrNothingToUndo)
+		utils.ValidateResult(t, m.Redo(), ErrNothingToRedo)
+	})
+
+	t.Run("Clears the redo stack on a new command", func(t *testing.T) {
+		doc := &document{}
+		m := New()
+		typeText(m, doc, "a b")
+
+		m.Undo()
+		typeText(m, doc, " c")
+
+		utils.ValidateResult(t, m.CanRedo(), false)
+		utils.ValidateResult(t, m.Redo(), ErrNothingToRedo)
+		utils.ValidateResult(t, doc.text, "a c")
+	})
+
+	t.Run("Keeps a command whose undo fails", func(t *testing.T) {
+		doc := &document{}
+		cmd := &failing{doc: doc, broken: true}
+		m := New()
+		m.Execute(cmd)
+
+		utils.ValidateResult(t, m.Undo().Error(), "broken")
+		utils.ValidateResult(t, m.State().UndoLen, 1)
+
+		cmd.broken = false
+		utils.ValidateResult(t, m.Undo(), nil)
+		utils.ValidateResult(t, doc.text, "")
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("Undoes a group as one", func(t *testing.T) {
+		doc := &document{}
+		m := New()
+		typeText(m, doc, "x ")
+
+		m.BeginGroup()
+		m.Execute(&failing{doc: doc})
+		m.BeginGroup()
+		m.Execute(&failing{doc: doc})
+		m.EndGroup()
+		m.Execute(&failing{doc: doc})
+		utils.ValidateResult(t, m.EndGroup(), nil)
+
+		utils.ValidateResult(t, doc.text, "x !!!")
+		utils.ValidateResult(t, m.Undo(), nil)
+		utils.ValidateResult(t, doc.text, "x ")
+		utils.ValidateResult(t, m.Redo(), nil)
+		utils.ValidateResult(t, doc.text, "x !!!")
+	})
+
+	t.Run("Rolls back a group whose undo fails part way", func(t *testing.T) {
+		doc := &document{}
+		m := New()
+
+		m.BeginGroup()
+		m.Execute(&failing{doc: doc, broken: true})
+		m.Execute(&failing{doc: doc})
+		m.EndGroup()
+
+		utils.ValidateResult(t, m.Undo().Error(), "broken")
+		utils.ValidateResult(t, doc.text, "!!")
+		utils.ValidateResult(t, m.CanUndo(), true)
+	})
+
+	t.Run("Refuses to undo while a group is open", func(t *testing.T) {
+		doc := &document{}
+		m := New()
+		typeText(m, doc, "a")
+
+		m.BeginGroup()
+		utils.ValidateResult(t, m.Undo(), ErrGroupOpen)
+		utils.ValidateResult(t, m.CanUndo(), false)
+	})
+
+	t.Run("Records nothing for an empty group", func(t *testing.T) {
+		m := New()
+		m.BeginGroup()
+
+		utils.ValidateResult(t, m.EndGroup(), nil)
+		utils.ValidateResult(t, m.CanUndo(), false)
+		utils.ValidateResult(t, m.EndGroup(), ErrNoGroup)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	doc := &document{}
+	m := New()
+	typeText(m, doc, "hello world")
+
+	utils.ValidateResult(t, m.State().UndoLen, 2)
+
+	m.Undo()
+	utils.ValidateResult(t, doc.text, "hello")
+	m.Undo()
+	utils.ValidateResult(t, doc.text, "")
+
+	m.Redo()
+	utils.ValidateResult(t, doc.text, "hello")
+}
+
+func TestLimit(t *testing.T) {
+	doc := &document{}
+	m := New(WithLimit(2))
+	typeText(m, doc, "a b c")
+
+	utils.ValidateResult(t, m.State().UndoLen, 2)
+
+	m.Undo()
+	m.Undo()
+	utils.ValidateResult(t, m.Undo(), ErrNothingToUndo)
+	utils.ValidateResult(t, doc.text, "a")
+}
+
+func TestOnChange(t *testing.T) {
+	var states []State
+	doc := &document{}
+	m := New(WithOnChange(func(s State) { states = append(states, s) }))
+
+	typeText(m, doc, "a")
+	m.Undo()
+	m.Redo()
+
+	want := []State{
+		{CanUndo: true, UndoLen: 1},
+		{CanRedo: true, RedoLen: 1},
+		{CanUndo: true, UndoLen: 1},
+	}
+
+	utils.ValidateResult(t, len(states), len(want))
+	for i := range want {
+		utils.ValidateResult(t, states[i], want[i])
+	}
+}
@@ -0,0 +1,124 @@
+// Package window answers aggregate queries over the most recent part of a
+// stream: the largest, smallest, total and average of the items still in the
+// window, each in amortized O(1).
+package window
+
+import (
+	"cmp"
+	"sync"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/deque"
+)
+
+type entry[T any] struct {
+	value T
+	seq   uint64
+}
+
+// MonotonicQueue is a FIFO queue that reports its largest and smallest items.
+// Instead of the items themselves it keeps two deques of candidates: one whose
+// values decrease from front to back for Max, and one whose values increase
+// for Min. A push evicts the candidates it dominates from the back, so each
+// item enters and leaves each deque at most once.
+type MonotonicQueue[T cmp.Ordered] struct {
+	maxima *deque.Deque[entry[T]]
+	minima *deque.Deque[entry[T]]
+	pushed uint64
+	popped uint64
+	mu     sync.Mutex
+}
+
+func NewMonotonicQueue[T cmp.Ordered]() *MonotonicQueue[T] {
+	return &MonotonicQueue[T]{
+		maxima: deque.New[entry[T]](),
+		minima: deque.New[entry[T]](),
+	}
+}
+
+func (q *MonotonicQueue[T]) Push(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.push(item)
+}
+
+// Pop removes the oldest item. It returns false if the queue is empty.
+func (q *MonotonicQueue[T]) Pop() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pop()
+}
+
+func (q *MonotonicQueue[T]) Max() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return front(q.maxima)
+}
+
+func (q *MonotonicQueue[T]) Min() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return front(q.minima)
+}
+
+func (q *MonotonicQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return int(q.pushed - q.popped)
+}
+
+func (q *MonotonicQueue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+func (q *MonotonicQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.maxima.Clear()
+	q.minima.Clear()
+	q.popped = q.pushed
+}
+
+func (q *MonotonicQueue[T]) push(item T) {
+	e := entry[T]{item, q.pushed}
+	q.pushed++
+
+	for last, ok := q.maxima.Back(); ok && last.value < item; last, ok = q.maxima.Back() {
+		q.maxima.PopBack()
+	}
+	q.maxima.PushBack(e)
+
+	for last, ok := q.minima.Back(); ok && last.value > item; last, ok = q.minima.Back() {
+		q.minima.PopBack()
+	}
+	q.minima.PushBack(e)
+}
+
+func (q *MonotonicQueue[T]) pop() bool {
+	if q.popped == q.pushed {
+		return false
+	}
+
+	seq := q.popped
+	q.popped++
+
+	// The oldest item is only still a candidate if nothing pushed after it
+	// dominated it.
+	if first, _ := q.maxima.Front(); first.seq == seq {
+		q.maxima.PopFront()
+	}
+
+	if first, _ := q.minima.Front(); first.seq == seq {
+		q.minima.PopFront()
+	}
+
+	return true
+}
+
+func front[T any](candidates *deque.Deque[entry[T]]) (T, bool) {
+	first, ok := candidates.Front()
+	return first.value, ok
+}
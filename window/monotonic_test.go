@@ -0,0 +1,77 @@
+package window
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestMonotonicQueue(t *testing.T) {
+	t.Run("Reports the extremes of an empty queue as absent", func(t *testing.T) {
+		q := NewMonotonicQueue[int]()
+
+		_, ok := q.Max()
+		utils.ValidateResult(t, ok, false)
+
+		_, ok = q.Min()
+		utils.ValidateResult(t, ok, false)
+
+		utils.ValidateResult(t, q.Pop(), false)
+	})
+
+	t.Run("Keeps a dominated item once the dominating one is popped", func(t *testing.T) {
+		q := NewMonotonicQueue[int]()
+		q.Push(3)
+		q.Push(1)
+		q.Push(2)
+
+		q.Pop()
+		max, _ := q.Max()
+		min, _ := q.Min()
+
+		utils.ValidateResult(t, max, 2)
+		utils.ValidateResult(t, min, 1)
+	})
+
+	t.Run("Keeps duplicates of the extreme", func(t *testing.T) {
+		q := NewMonotonicQueue[int]()
+		q.Push(5)
+		q.Push(5)
+
+		q.Pop()
+		max, ok := q.Max()
+
+		utils.ValidateResult(t, ok, true)
+		utils.ValidateResult(t, max, 5)
+	})
+
+	t.Run("Matches a slice under random pushes and pops", func(t *testing.T) {
+		q := NewMonotonicQueue[int]()
+		var model []int
+		r := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 10000; i++ {
+			if r.Intn(3) == 0 {
+				utils.ValidateResult(t, q.Pop(), len(model) > 0)
+				if len(model) > 0 {
+					model = model[1:]
+				}
+			} else {
+				item := r.Intn(100)
+				q.Push(item)
+				model = append(model, item)
+			}
+
+			utils.ValidateResult(t, q.Len(), len(model))
+
+			if len(model) > 0 {
+				max, _ := q.Max()
+				min, _ := q.Min()
+				utils.ValidateResult(t, max, slices.Max(model))
+				utils.ValidateResult(t, min, slices.Min(model))
+			}
+		}
+	})
+}
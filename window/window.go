@@ -0,0 +1,163 @@
+package window
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/constraints"
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/deque"
+)
+
+type config struct {
+	clock clock.Clock
+}
+
+type Option func(*config)
+
+// WithClock sets the source of time for a time-based window, which tests can
+// replace to advance time without sleeping.
+func WithClock(clk clock.Clock) Option {
+	return func(c *config) { c.clock = clk }
+}
+
+type sample[T any] struct {
+	value T
+	at    time.Time
+}
+
+// SlidingWindow aggregates the items pushed most recently: either the last
+// size items, or the items pushed within the last span of time. Sum is kept
+// as a running total, so with floating-point items it can drift from the
+// exact sum of the window by the usual rounding error.
+type SlidingWindow[T constraints.Number] struct {
+	samples  *deque.Deque[sample[T]]
+	extremes *MonotonicQueue[T]
+	sum      T
+	len      int
+	size     int
+	span     time.Duration
+	clock    clock.Clock
+	mu       sync.Mutex
+}
+
+// NewCount creates a window over the last size items. Sizes below 1 are
+// raised to 1.
+func NewCount[T constraints.Number](size int) *SlidingWindow[T] {
+	return &SlidingWindow[T]{
+		samples:  deque.New[sample[T]](),
+		extremes: NewMonotonicQueue[T](),
+		size:     max(size, 1),
+		clock:    clock.Real{},
+	}
+}
+
+// NewTime creates a window over the items pushed less than span ago.
+func NewTime[T constraints.Number](span time.Duration, opts ...Option) *SlidingWindow[T] {
+	c := config{clock: clock.Real{}}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &SlidingWindow[T]{
+		samples:  deque.New[sample[T]](),
+		extremes: NewMonotonicQueue[T](),
+		span:     span,
+		clock:    c.clock,
+	}
+}
+
+func (w *SlidingWindow[T]) Push(item T) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var at time.Time
+	if w.isTimed() {
+		at = w.clock.Now()
+	}
+
+	w.samples.PushBack(sample[T]{item, at})
+	w.extremes.push(item)
+	w.sum += item
+	w.len++
+
+	w.evict()
+}
+
+func (w *SlidingWindow[T]) Max() (T, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict()
+	return front(w.extremes.maxima)
+}
+
+func (w *SlidingWindow[T]) Min() (T, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict()
+	return front(w.extremes.minima)
+}
+
+// Sum returns the total of the items in the window, or zero if it is empty.
+func (w *SlidingWindow[T]) Sum() T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict()
+	return w.sum
+}
+
+// Mean returns the average of the items in the window. It returns false if
+// the window is empty.
+func (w *SlidingWindow[T]) Mean() (float64, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict()
+
+	if w.len == 0 {
+		return 0, false
+	}
+
+	return float64(w.sum) / float64(w.len), true
+}
+
+func (w *SlidingWindow[T]) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict()
+	return w.len
+}
+
+func (w *SlidingWindow[T]) IsEmpty() bool { return w.Len() == 0 }
+
+func (w *SlidingWindow[T]) isTimed() bool { return w.size == 0 }
+
+// evict drops the items that have slid out of the window. Time-based windows
+// call it on every query, since items expire without anything being pushed.
+func (w *SlidingWindow[T]) evict() {
+	var cutoff time.Time
+	if w.isTimed() {
+		cutoff = w.clock.Now().Add(-w.span)
+	}
+
+	for w.len > 0 {
+		oldest, _ := w.samples.Front()
+
+		if w.isTimed() && oldest.at.After(cutoff) || !w.isTimed() && w.len <= w.size {
+			return
+		}
+
+		w.samples.PopFront()
+		w.extremes.pop()
+		w.sum -= oldest.value
+		w.len--
+	}
+
+	// Starting an empty window from zero stops rounding error carrying over.
+	var zero T
+	w.sum = zero
+}
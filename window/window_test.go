@@ -0,0 +1,67 @@
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gyuudon3187/go-data-structures-and-algorithms/clock"
+	utils "github.com/gyuudon3187/go-data-structures-and-algorithms/test_utils"
+)
+
+func TestCountWindow(t *testing.T) {
+	t.Run("Aggregates the last size items", func(t *testing.T) {
+		w := NewCount[int](3)
+		for _, item := range []int{9, 1, 4, 2, 6} {
+			w.Push(item)
+		}
+
+		max, _ := w.Max()
+		min, _ := w.Min()
+		mean, _ := w.Mean()
+
+		utils.ValidateResult(t, w.Len(), 3)
+		utils.ValidateResult(t, max, 6)
+		utils.ValidateResult(t, min, 2)
+		utils.ValidateResult(t, w.Sum(), 12)
+		utils.ValidateResult(t, mean, 4.0)
+	})
+
+	t.Run("Reports an empty window", func(t *testing.T) {
+		w := NewCount[float64](3)
+
+		_, ok := w.Max()
+		utils.ValidateResult(t, ok, false)
+
+		_, ok = w.Mean()
+		utils.ValidateResult(t, ok, false)
+
+		utils.ValidateResult(t, w.Sum(), 0.0)
+	})
+}
+
+func TestTimeWindow(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	w := NewTime[int](time.Minute, WithClock(fake))
+
+	w.Push(10)
+	fake.Advance(30 * time.Second)
+	w.Push(2)
+	w.Push(4)
+
+	max, _ := w.Max()
+	utils.ValidateResult(t, max, 10)
+	utils.ValidateResult(t, w.Sum(), 16)
+
+	fake.Advance(30 * time.Second)
+
+	max, _ = w.Max()
+	min, _ := w.Min()
+	utils.ValidateResult(t, w.Len(), 2)
+	utils.ValidateResult(t, max, 4)
+	utils.ValidateResult(t, min, 2)
+
+	fake.Advance(time.Minute)
+
+	utils.ValidateResult(t, w.IsEmpty(), true)
+	utils.ValidateResult(t, w.Sum(), 0)
+}